@@ -0,0 +1,336 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+)
+
+var (
+	ErrAckDisabled      = errors.New("acknowledged subscriptions are disabled")
+	ErrConsumerMissing  = errors.New("missing consumer")
+	ErrConsumerReplaced = errors.New("consumer connected by another stream")
+)
+
+// AckConfig holds the config of acknowledged subscriptions
+type AckConfig struct {
+	// Enabled whether to allow SubscribeAcked
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// MaxPending is the amount of unacknowledged messages kept per consumer,
+	// the oldest message is dropped once exceeded
+	MaxPending int `mapstructure:"maxPending" default:"10000"`
+
+	// Retention is the time a disconnected consumer keeps receiving messages
+	Retention time.Duration `mapstructure:"retention" default:"1h"`
+}
+
+// Validate validates the AckConfig
+func (c *AckConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxPending <= 0 {
+		return fmt.Errorf("invalid rpc.ack.maxPending")
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("invalid rpc.ack.retention")
+	}
+
+	return nil
+}
+
+// ackEntry is a message retained until acknowledged
+type ackEntry struct {
+	id   uint64
+	resp *v1.SubscribeResponse
+
+	// sent is set once sent on the current connection
+	sent bool
+	// delivered is set once sent on any connection
+	delivered bool
+}
+
+// ackConsumer is a durable subscription of SubscribeAcked
+type ackConsumer struct {
+	// key identifies the consumer by identity and name
+	key string
+
+	// maxPending is the limit of pending
+	maxPending int
+
+	// sub receives the messages of the consumer
+	sub *subscriber
+	// unsubscribe unregisters sub
+	unsubscribe func()
+
+	// pending stores the unacknowledged messages ordered by id
+	pending []*ackEntry
+	// nextID is the delivery id of the next message
+	nextID uint64
+	// connected is set while a stream serves the consumer
+	connected bool
+	// connection counts the streams, only the latest one serves the consumer
+	connection uint64
+	// cancel ends the latest stream once another stream takes over
+	cancel context.CancelCauseFunc
+	// expiry unsubscribes the consumer once disconnected for too long
+	expiry *time.Timer
+	// m_pending synchronizes access to the fields above
+	m_pending sync.Mutex
+
+	// notify wakes up the sending stream
+	notify chan struct{}
+}
+
+// enqueue retains resp and wakes up the stream, dropping the oldest
+// message if maxPending is exceeded
+func (c *ackConsumer) enqueue(resp *v1.SubscribeResponse) error {
+	c.m_pending.Lock()
+	c.nextID++
+	c.pending = append(c.pending, &ackEntry{id: c.nextID, resp: resp})
+	dropped := len(c.pending) > c.maxPending
+	if dropped {
+		c.pending = append(c.pending[:0:0], c.pending[1:]...)
+	}
+	c.m_pending.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+
+	if dropped {
+		return errors.New("too many unacknowledged messages, dropped the oldest")
+	}
+
+	return nil
+}
+
+// ack forgets the messages of ids
+func (c *ackConsumer) ack(ids []uint64) {
+	c.m_pending.Lock()
+	defer c.m_pending.Unlock()
+
+	acked := map[uint64]bool{}
+	for _, id := range ids {
+		acked[id] = true
+	}
+	pending := c.pending[:0]
+	for _, entry := range c.pending {
+		if !acked[entry.id] {
+			pending = append(pending, entry)
+		}
+	}
+	clear(c.pending[len(pending):])
+	c.pending = pending
+}
+
+// unsent returns the pending messages not sent on connection and marks
+// them as sent, nil if another connection took over
+func (c *ackConsumer) unsent(connection uint64) []*v1.SubscribeAckedResponse {
+	c.m_pending.Lock()
+	defer c.m_pending.Unlock()
+
+	if connection != c.connection {
+		return nil
+	}
+
+	ret := []*v1.SubscribeAckedResponse{}
+	for _, entry := range c.pending {
+		if entry.sent {
+			continue
+		}
+		ret = append(ret, &v1.SubscribeAckedResponse{
+			DeliveryId:  entry.id,
+			Message:     entry.resp,
+			Redelivered: entry.delivered,
+		})
+		entry.sent = true
+		entry.delivered = true
+	}
+
+	return ret
+}
+
+// SubscribeAcked implements knx.groupaddressservice.v1.SubscribeAcked
+func (s *Server) SubscribeAcked(
+	ctx context.Context,
+	stream *connect.BidiStream[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse],
+) error {
+	if !s.config.RPC.Ack.Enabled {
+		return connect.NewError(connect.CodeFailedPrecondition, ErrAckDisabled)
+	}
+
+	// the first message names the consumer
+	req, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	if len(req.Consumer) == 0 {
+		return newConnectError(connect.CodeInvalidArgument,
+			fieldErrorf("consumer", "%s", ErrConsumerMissing))
+	}
+
+	// a reconnecting stream takes over by canceling this one
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	consumer, connection, err := s.connectConsumer(ctx, cancel,
+		s.trustedProxies.peerAddr(stream.Peer().Addr, stream.RequestHeader()), req)
+	if err != nil {
+		return err
+	}
+	defer s.disconnectConsumer(consumer, connection)
+	consumer.ack(req.Acks)
+
+	// receive acks until the client closes its side
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Receive()
+			if err != nil {
+				received <- err
+				return
+			}
+			consumer.ack(req.Acks)
+		}
+	}()
+
+	// send pending messages whenever notified
+	for {
+		for _, resp := range consumer.unsent(connection) {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-consumer.notify:
+		case <-received:
+			return nil
+		case <-ctx.Done():
+			if errors.Is(context.Cause(ctx), ErrConsumerReplaced) {
+				return connect.NewError(connect.CodeAborted, ErrConsumerReplaced)
+			}
+			return nil
+		case <-s.ctx.Done():
+			return connect.NewError(connect.CodeAborted, s.ctx.Err())
+		}
+	}
+}
+
+// connectConsumer returns the consumer named by req and the connection of
+// the stream of peer taking it over, creating a new consumer if needed.
+// cancel ends the stream once another stream takes over.
+func (s *Server) connectConsumer(
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+	peer string,
+	req *v1.SubscribeAckedRequest,
+) (*ackConsumer, uint64, error) {
+	key := identityFromContext(ctx) + "/" + req.Consumer
+
+	s.m_ackConsumers.Lock()
+	defer s.m_ackConsumers.Unlock()
+
+	consumer, ok := s.ackConsumers[key]
+	if !ok {
+		subReq := req.SubscribeRequest
+		if subReq == nil {
+			subReq = &v1.SubscribeRequest{}
+		}
+		sub, err := newSubscriber(ctx, peer, subReq)
+		if err != nil {
+			return nil, 0, connect.NewError(connect.CodeInternal, err)
+		}
+		sub.fields, err = newFieldMask(&v1.SubscribeResponse{}, subReq.Fields)
+		if err != nil {
+			return nil, 0, newConnectError(connect.CodeInvalidArgument, err)
+		}
+		consumer = &ackConsumer{
+			key:        key,
+			maxPending: s.config.RPC.Ack.MaxPending,
+			sub:        sub,
+			notify:     make(chan struct{}, 1),
+		}
+		sub.consumer = consumer
+
+		consumer.unsubscribe, err = s.subscribe(sub)
+		if err != nil {
+			return nil, 0, err
+		}
+		s.ackConsumers[key] = consumer
+	}
+
+	consumer.m_pending.Lock()
+	defer consumer.m_pending.Unlock()
+
+	if consumer.connected {
+		// the previous stream may not have noticed its client is gone
+		consumer.cancel(ErrConsumerReplaced)
+	}
+	consumer.connected = true
+	consumer.connection++
+	consumer.cancel = cancel
+	if consumer.expiry != nil {
+		consumer.expiry.Stop()
+	}
+	// resend everything unacknowledged on this connection
+	for _, entry := range consumer.pending {
+		entry.sent = false
+	}
+
+	return consumer, consumer.connection, nil
+}
+
+// disconnectConsumer marks consumer as disconnected unless another stream
+// took over connection, it is unsubscribed unless reconnected within
+// rpc.ack.retention
+func (s *Server) disconnectConsumer(consumer *ackConsumer, connection uint64) {
+	consumer.m_pending.Lock()
+	defer consumer.m_pending.Unlock()
+
+	if connection != consumer.connection {
+		return
+	}
+	consumer.connected = false
+	consumer.expiry = time.AfterFunc(s.config.RPC.Ack.Retention, func() {
+		s.m_ackConsumers.Lock()
+		defer s.m_ackConsumers.Unlock()
+
+		consumer.m_pending.Lock()
+		connected := consumer.connected
+		consumer.m_pending.Unlock()
+		if connected || s.ackConsumers[consumer.key] != consumer {
+			return
+		}
+
+		delete(s.ackConsumers, consumer.key)
+		consumer.unsubscribe()
+		s.log.Info().
+			Str("consumer", consumer.key).
+			Msg("acknowledged subscription expired")
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"sync"
+
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// formatted addresses are cached as a bus only uses a few of them
+var (
+	groupAddrStrings      = &addrCache[cemi.GroupAddr]{strings: map[cemi.GroupAddr]string{}}
+	individualAddrStrings = &addrCache[cemi.IndividualAddr]{strings: map[cemi.IndividualAddr]string{}}
+)
+
+// addrCache maps addresses to their formatted string,
+// it is bounded by the 2^16 possible addresses.
+type addrCache[T interface {
+	~uint16
+	String() string
+}] struct {
+	m_strings sync.RWMutex
+	strings   map[T]string
+}
+
+// get returns the formatted string of addr
+func (c *addrCache[T]) get(addr T) string {
+	c.m_strings.RLock()
+	s, ok := c.strings[addr]
+	c.m_strings.RUnlock()
+	if ok {
+		return s
+	}
+
+	s = addr.String()
+	c.m_strings.Lock()
+	c.strings[addr] = s
+	c.m_strings.Unlock()
+
+	return s
+}
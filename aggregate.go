@@ -0,0 +1,181 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/dpt"
+)
+
+// maxAggregateBuckets limits the amount of buckets of a single aggregation
+const maxAggregateBuckets = 10000
+
+// aggregateOptions holds the parsed parameters of an aggregation
+type aggregateOptions struct {
+	ga     cemi.GroupAddr
+	dpt    string
+	from   time.Time
+	to     time.Time
+	bucket time.Duration
+}
+
+// parseAggregateRequest returns aggregateOptions from req or error
+func (s *Server) parseAggregateRequest(req *v1.AggregateRequest) (*aggregateOptions, error) {
+	opts := &aggregateOptions{
+		dpt: req.Dpt,
+		to:  time.Now(),
+	}
+
+	var err error
+	opts.ga, err = cemi.NewGroupAddrString(req.GroupAddress)
+	if err != nil {
+		return nil, fieldErrorf("group_address", "parse groupAddress: %s", err)
+	}
+
+	if len(opts.dpt) == 0 {
+		return nil, fieldErrorf("dpt", "missing dpt")
+	}
+	if _, ok := dpt.Produce(opts.dpt); !ok {
+		return nil, fieldErrorf("dpt", "unsupported dpt: %s", opts.dpt)
+	}
+
+	if len(req.To) > 0 {
+		opts.to, err = time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, fieldErrorf("to", "parsing 'to': %v", err)
+		}
+	}
+	opts.from = opts.to.Add(-s.config.RPC.History.Retention)
+	if len(req.From) > 0 {
+		opts.from, err = time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, fieldErrorf("from", "parsing 'from': %v", err)
+		}
+	}
+	if !opts.from.Before(opts.to) {
+		return nil, fieldErrorf("from", "from %s is not before to %s", opts.from, opts.to)
+	}
+
+	opts.bucket = opts.to.Sub(opts.from)
+	if len(req.Bucket) > 0 {
+		opts.bucket, err = time.ParseDuration(req.Bucket)
+		if err != nil {
+			return nil, fieldErrorf("bucket", "parsing 'bucket': %v", err)
+		}
+		if opts.bucket <= 0 {
+			return nil, fieldErrorf("bucket", "invalid bucket %s", opts.bucket)
+		}
+	}
+	if opts.to.Sub(opts.from)/opts.bucket >= maxAggregateBuckets {
+		return nil, fieldErrorf("bucket", "too many buckets, maximum is %d", maxAggregateBuckets)
+	}
+
+	return opts, nil
+}
+
+// aggregate returns statistics of the recorded values within opts
+func (s *Server) aggregate(opts *aggregateOptions) (*v1.AggregateResponse, error) {
+	entries, err := s.history.query(opts.ga, opts.from, opts.to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &v1.AggregateResponse{
+		Buckets: []*v1.AggregateBucket{},
+	}
+
+	var bucket *v1.AggregateBucket
+	var bucketIndex int64 = -1
+	var sum float64
+	for _, e := range entries {
+		if e.event.Command == knx.GroupRead {
+			continue
+		}
+		value, ok := numericValue(opts.dpt, e.event.Data)
+		if !ok {
+			continue
+		}
+
+		// start a new bucket if required
+		i := int64(e.time.Sub(opts.from) / opts.bucket)
+		if i != bucketIndex {
+			if bucket != nil {
+				bucket.Avg = sum / float64(bucket.Count)
+			}
+			start := opts.from.Add(time.Duration(i) * opts.bucket)
+			bucket = &v1.AggregateBucket{
+				Start: start.Format(time.RFC3339),
+				End:   start.Add(opts.bucket).Format(time.RFC3339),
+				Min:   math.Inf(1),
+				Max:   math.Inf(-1),
+			}
+			res.Buckets = append(res.Buckets, bucket)
+			bucketIndex = i
+			sum = 0
+		}
+
+		bucket.Count++
+		bucket.Min = math.Min(bucket.Min, value)
+		bucket.Max = math.Max(bucket.Max, value)
+		bucket.Last = value
+		sum += value
+	}
+	if bucket != nil {
+		bucket.Avg = sum / float64(bucket.Count)
+	}
+
+	return res, nil
+}
+
+// numericValue decodes data using datapoint type dptName
+// and returns it as float64 if it is numeric.
+func numericValue(dptName string, data []byte) (float64, bool) {
+	dp, ok := dpt.Produce(dptName)
+	if !ok {
+		return 0, false
+	}
+	if err := dp.Unpack(data); err != nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(dp)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, true
+		}
+		return 0, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
+	v2Connect "github.com/choopm/knxrpc/knx/groupaddress/v2/v2connect"
+)
+
+var (
+	ErrAPIKeyExpired    = errors.New("api key expired")
+	ErrRoleInsufficient = errors.New("role not allowed to call procedure")
+)
+
+// Role of an API key
+type Role string
+
+const (
+	// RoleReader may subscribe and list
+	RoleReader Role = "reader"
+	// RoleWriter may additionally publish to the bus
+	RoleWriter Role = "writer"
+	// RoleAdmin may additionally manage devices
+	RoleAdmin Role = "admin"
+)
+
+// level returns the privilege level of r, 0 if unknown
+func (r Role) level() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleWriter:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+
+	return 0
+}
+
+// allows returns whether r may act as required
+func (r Role) allows(required Role) bool {
+	return r.level() >= required.level()
+}
+
+// procedureRoles lists the role required by procedures,
+// any procedure not listed requires RoleAdmin.
+var procedureRoles = map[string]Role{
+	v1Connect.GroupAddressServiceSubscribeProcedure:          RoleReader,
+	v1Connect.GroupAddressServiceSubscribeUnaryProcedure:     RoleReader,
+	v1Connect.GroupAddressServiceSubscribeAckedProcedure:     RoleReader,
+	v1Connect.GroupAddressServiceListScheduledProcedure:      RoleReader,
+	v1Connect.GroupAddressServiceAggregateProcedure:          RoleReader,
+	v1Connect.GroupAddressServiceListGroupAddressesProcedure: RoleReader,
+	v1Connect.GroupAddressServicePublishProcedure:            RoleWriter,
+	v1Connect.GroupAddressServiceScanProcedure:               RoleWriter,
+	v1Connect.GroupAddressServiceCancelScheduledProcedure:    RoleWriter,
+	v1Connect.GroupAddressServiceTransactionProcedure:        RoleWriter,
+	v1Connect.GroupAddressServiceCommitTransactionProcedure:  RoleWriter,
+	v1Connect.GroupAddressServiceRevertTransactionProcedure:  RoleWriter,
+	v2Connect.GroupAddressServiceSubscribeProcedure:          RoleReader,
+	v2Connect.GroupAddressServicePublishProcedure:            RoleWriter,
+}
+
+// requiredRole returns the role required to call procedure
+func requiredRole(procedure string) Role {
+	if role, ok := procedureRoles[procedure]; ok {
+		return role
+	}
+
+	return RoleAdmin
+}
+
+// APIKeyConfig holds the config of a named API key
+type APIKeyConfig struct {
+	// Name identifies the key in logs, required
+	Name string `mapstructure:"name"`
+
+	// SecretKey is the key to compare the rpc.auth.header value with, required
+	SecretKey string `mapstructure:"secretKey"`
+
+	// SecretKeyFile is a file to read SecretKey from, optional
+	SecretKeyFile string `mapstructure:"secretKeyFile"`
+
+	// Role of the key, oneof: admin|writer|reader
+	Role Role `mapstructure:"role" default:"reader"`
+
+	// Expires is the time the key stops being valid, optional
+	// valid format: 2024-01-02T15:04:05Z
+	Expires time.Time `mapstructure:"expires"`
+}
+
+// Validate validates the APIKeyConfig
+func (c *APIKeyConfig) Validate() error {
+	if len(c.Name) == 0 {
+		return fmt.Errorf("missing rpc.auth.keys.name")
+	}
+	if len(c.SecretKey) == 0 {
+		return fmt.Errorf("missing rpc.auth.keys(%s).secretKey", c.Name)
+	}
+	if c.Role.level() == 0 {
+		return fmt.Errorf("invalid rpc.auth.keys(%s).role: %s", c.Name, c.Role)
+	}
+
+	return nil
+}
+
+// apiKey is an authenticated named API key
+type apiKey struct {
+	config *APIKeyConfig
+}
+
+// newAPIKeys returns all configured API keys
+func newAPIKeys(configs []APIKeyConfig) []*apiKey {
+	ret := []*apiKey{}
+
+	for i := range configs {
+		ret = append(ret, &apiKey{
+			config: &configs[i],
+		})
+	}
+
+	return ret
+}
+
+// expired returns whether the key is expired at now
+func (k *apiKey) expired(now time.Time) bool {
+	return !k.config.Expires.IsZero() && !now.Before(k.config.Expires)
+}
@@ -18,11 +18,11 @@ package knxrpc
 
 import (
 	"context"
-	"crypto/subtle"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
@@ -43,8 +43,23 @@ type AuthConfig struct {
 	// Scheme defines the auth scheme which is stripped from the header value
 	Scheme string `mapstructure:"scheme" default:"Bearer"`
 
-	// SecretKey is the key to compare the Header value with, required if [Enabled]
+	// SecretKey is the key to compare the Header value with,
+	// required if [Enabled] unless Keys are given
 	SecretKey string `mapstructure:"secretKey" default:""`
+
+	// SecretKeyFile is a file to read SecretKey from, optional
+	SecretKeyFile string `mapstructure:"secretKeyFile"`
+
+	// Keys are additional named keys having a role and optional expiry,
+	// only used by rpc.auth
+	Keys []APIKeyConfig `mapstructure:"keys"`
+
+	// LDAP authenticates HTTP Basic credentials against a directory,
+	// only used by rpc.auth
+	LDAP LDAPConfig `mapstructure:"ldap"`
+
+	// HMAC signs (client) or verifies (server) publish requests
+	HMAC HMACConfig `mapstructure:"hmac"`
 }
 
 // Validate validates the AuthConfig
@@ -56,28 +71,167 @@ func (c *AuthConfig) Validate() error {
 	if len(c.Header) == 0 {
 		return fmt.Errorf("missing server.auth.header")
 	}
-	if len(c.SecretKey) == 0 {
+	if len(c.SecretKey) == 0 && len(c.Keys) == 0 && !c.LDAP.Enabled && !c.HMAC.Enabled {
 		return fmt.Errorf("missing server.auth.secretKey")
 	}
+	if err := validateSecret(c.SecretKey); err != nil {
+		return fmt.Errorf("invalid server.auth.secretKey: %s", err)
+	}
+	names := map[string]bool{}
+	for i := range c.Keys {
+		if err := c.Keys[i].Validate(); err != nil {
+			return err
+		}
+		if err := validateSecret(c.Keys[i].SecretKey); err != nil {
+			return fmt.Errorf("invalid rpc.auth.keys(%s).secretKey: %s", c.Keys[i].Name, err)
+		}
+		if names[c.Keys[i].Name] {
+			return fmt.Errorf("duplicate rpc.auth.keys.name %s", c.Keys[i].Name)
+		}
+		names[c.Keys[i].Name] = true
+	}
+	if err := c.LDAP.Validate(); err != nil {
+		return err
+	}
+	if err := c.HMAC.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // authenticateRPC authenticates RPCs using a middleware
 func (s *Server) authenticateRPC(ctx context.Context, req *http.Request) (any, error) {
+	procedure, _ := authn.InferProcedure(req.URL)
+
+	// signed publish requests authenticate using their signature
+	if s.hmac != nil && hmacSigned(procedure) {
+		err := s.hmac.verify(req, procedure, s.secret(s.config.RPC.Auth.HMAC.SecretKey))
+		switch {
+		case err == nil:
+			return s.authorizeAPIKey(s.hmac.key, procedure)
+		case !errors.Is(err, ErrSignatureMissing):
+			s.log.Warn().
+				Err(err).
+				Str("procedure", procedure).
+				Msg("signature verification failed")
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		case s.config.RPC.Auth.HMAC.Required:
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+	}
+
+	// directory users authenticate using HTTP Basic credentials
+	if username, password, ok := req.BasicAuth(); ok && s.ldap != nil {
+		key, err := s.ldap.authenticate(ctx, username, password)
+		if err != nil {
+			s.log.Warn().
+				Err(err).
+				Str("username", username).
+				Msg("ldap authentication failed")
+			if errors.Is(err, ErrLDAPInvalidCredentials) || errors.Is(err, ErrLDAPNoRole) {
+				return nil, connect.NewError(connect.CodeUnauthenticated, err)
+			}
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+
+		return s.authorizeAPIKey(key, procedure)
+	}
+
 	// fetch value
 	val := req.Header.Get(s.config.RPC.Auth.Header)
 	if len(val) == 0 {
 		return nil, authn.Errorf("missing %s header", s.config.RPC.Auth.Header)
 	}
 
-	// currently only static key comparison is supported:
-	err := s.authenticateStaticSecretKey(val)
+	// the static secret key is unrestricted
+	if err := s.authenticateStaticSecretKey(val); err == nil {
+		return s.staticKey, nil
+	}
+
+	// named keys are restricted by their role
+	key, err := s.authenticateAPIKey(val)
+	if err == nil {
+		return s.authorizeAPIKey(key, procedure)
+	}
+	if !errors.Is(err, ErrInvalidAuthCredentials) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	// otherwise it has to be a tenant key
+	t, err := s.authenticateTenant(val)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	return nil, nil
+	s.log.Debug().
+		Str("identity", identityName(t)).
+		Str("procedure", procedure).
+		Msg("authenticated request")
+
+	return t, nil
+}
+
+// authorizeAPIKey returns key if its role allows calling procedure
+func (s *Server) authorizeAPIKey(key *apiKey, procedure string) (any, error) {
+	role := requiredRole(procedure)
+	if !key.config.Role.allows(role) {
+		s.log.Warn().
+			Str("identity", identityName(key)).
+			Str("procedure", procedure).
+			Msg("role not allowed")
+		return nil, newConnectError(connect.CodePermissionDenied,
+			fmt.Errorf("%w: %s requires %s", ErrRoleInsufficient, procedure, role))
+	}
+
+	s.log.Debug().
+		Str("identity", identityName(key)).
+		Str("procedure", procedure).
+		Msg("authenticated request")
+
+	return key, nil
+}
+
+// authenticateAPIKey returns the *apiKey matching the user provided value val,
+// ErrAPIKeyExpired if it expired or ErrInvalidAuthCredentials.
+func (s *Server) authenticateAPIKey(val string) (*apiKey, error) {
+	// strip scheme, trim space
+	val, _ = strings.CutPrefix(val, s.config.RPC.Auth.Scheme+" ")
+	val = strings.TrimSpace(val)
+
+	for _, key := range s.reloadable().apiKeys {
+		if !compareSecret(val, s.secret(key.config.SecretKey)) {
+			continue
+		}
+		if key.expired(time.Now()) {
+			s.log.Warn().
+				Str("identity", identityName(key)).
+				Msg("expired key used")
+			return nil, fmt.Errorf("%w: %s", ErrAPIKeyExpired, key.config.Name)
+		}
+
+		return key, nil
+	}
+
+	return nil, ErrInvalidAuthCredentials
+}
+
+// authenticateTenant returns the *tenant owning the user provided value val
+// or ErrInvalidAuthCredentials.
+func (s *Server) authenticateTenant(val string) (*tenant, error) {
+	// strip scheme, trim space
+	val, _ = strings.CutPrefix(val, s.config.RPC.Auth.Scheme+" ")
+	val = strings.TrimSpace(val)
+
+	for _, t := range s.reloadable().tenants {
+		for _, key := range t.config.SecretKeys {
+			if compareSecret(val, s.secret(key)) {
+				return t, nil
+			}
+		}
+	}
+
+	return nil, ErrInvalidAuthCredentials
 }
 
 // authenticateStaticSecretKey authenticates a user provided value val
@@ -87,9 +241,7 @@ func (s *Server) authenticateStaticSecretKey(val string) error {
 	val, _ = strings.CutPrefix(val, s.config.RPC.Auth.Scheme+" ")
 	val = strings.TrimSpace(val)
 
-	if subtle.ConstantTimeCompare(
-		[]byte(val),
-		[]byte(s.config.RPC.Auth.SecretKey)) != 1 {
+	if !compareSecret(val, s.secret(s.config.RPC.Auth.SecretKey)) {
 		return ErrInvalidAuthCredentials
 	}
 
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// AWSSecretsConfig holds the AWS Secrets Manager config
+type AWSSecretsConfig struct {
+	// Region of the secrets, required
+	Region string `mapstructure:"region"`
+
+	// AccessKeyID to authenticate with, optional (defaults to AWS_ACCESS_KEY_ID)
+	AccessKeyID string `mapstructure:"accessKeyID"`
+
+	// SecretAccessKey to authenticate with, optional (defaults to AWS_SECRET_ACCESS_KEY)
+	SecretAccessKey string `mapstructure:"secretAccessKey"`
+
+	// SecretAccessKeyFile is a file to read SecretAccessKey from, optional
+	SecretAccessKeyFile string `mapstructure:"secretAccessKeyFile"`
+
+	// SessionToken of temporary credentials, optional (defaults to AWS_SESSION_TOKEN)
+	SessionToken string `mapstructure:"sessionToken"`
+
+	// Endpoint overrides the regional endpoint, optional
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// Validate validates the AWSSecretsConfig
+func (c *AWSSecretsConfig) Validate() error {
+	if len(c.Region) == 0 {
+		return fmt.Errorf("missing rpc.secrets.aws.region")
+	}
+	if len(c.Endpoint) > 0 {
+		if _, err := url.Parse(c.Endpoint); err != nil {
+			return fmt.Errorf("invalid rpc.secrets.aws.endpoint: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// awsSecretsProvider fetches secrets from AWS Secrets Manager
+type awsSecretsProvider struct {
+	config *AWSSecretsConfig
+	client *http.Client
+}
+
+// newAWSSecretsProvider returns a *awsSecretsProvider from config
+func newAWSSecretsProvider(config *AWSSecretsConfig, timeout time.Duration) *awsSecretsProvider {
+	return &awsSecretsProvider{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// credentials returns the configured credentials or those of the environment
+func (p *awsSecretsProvider) credentials() (accessKeyID, secretAccessKey, sessionToken string) {
+	accessKeyID, secretAccessKey, sessionToken =
+		p.config.AccessKeyID, p.config.SecretAccessKey, p.config.SessionToken
+	if len(accessKeyID) == 0 {
+		accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+
+	return accessKeyID, secretAccessKey, sessionToken
+}
+
+// fetch implements secretsProvider.
+// path is the secret id, the whole SecretString is returned if key is empty,
+// otherwise key of the SecretString decoded as JSON object.
+func (p *awsSecretsProvider) fetch(ctx context.Context, path, key string) (string, error) {
+	endpoint := p.config.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", p.config.Region)
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": path})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	accessKeyID, secretAccessKey, sessionToken := p.credentials()
+	if len(accessKeyID) == 0 || len(secretAccessKey) == 0 {
+		return "", fmt.Errorf("missing aws credentials")
+	}
+	signAWSRequestV4(req, payload, "secretsmanager", p.config.Region,
+		accessKeyID, secretAccessKey, sessionToken, time.Now())
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close() // nolint:errcheck
+
+	if res.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return "", fmt.Errorf("aws returned %s: %s", res.Status, strings.TrimSpace(string(b)))
+	}
+
+	body := struct {
+		SecretString string `json:"SecretString"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding aws response: %s", err)
+	}
+	if len(key) == 0 {
+		return body.SecretString, nil
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal([]byte(body.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("decoding SecretString: %s", err)
+	}
+	val, ok := fields[key].(string)
+	if !ok {
+		return "", fmt.Errorf("missing string key %s", key)
+	}
+
+	return val, nil
+}
+
+// signAWSRequestV4 signs req having payload using AWS Signature Version 4
+func signAWSRequestV4(
+	req *http.Request,
+	payload []byte,
+	service, region string,
+	accessKeyID, secretAccessKey, sessionToken string,
+	now time.Time,
+) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if len(sessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	// canonical headers, sorted by lowercase name
+	headers := []string{"content-type", "host", "x-amz-date", "x-amz-target"}
+	if len(sessionToken) > 0 {
+		headers = append(headers, "x-amz-security-token")
+		headers[3], headers[4] = headers[4], headers[3]
+	}
+	canonicalHeaders := ""
+	for _, h := range headers {
+		val := req.Header.Get(h)
+		if h == "host" {
+			val = req.URL.Host
+		}
+		canonicalHeaders += h + ":" + strings.TrimSpace(val) + "\n"
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(payload)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	hmacSHA256 := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data)) // nolint:errcheck
+		return h.Sum(nil)
+	}
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
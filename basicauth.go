@@ -0,0 +1,140 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// BasicAuthConfig holds the HTTP Basic auth config of a webserver route
+type BasicAuthConfig struct {
+	// Enabled whether to accept HTTP Basic credentials
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Realm presented to browsers
+	Realm string `mapstructure:"realm" default:"knxrpc"`
+
+	// Users allowed to log in, required if [Enabled]
+	Users []BasicAuthUserConfig `mapstructure:"users"`
+}
+
+// BasicAuthUserConfig holds the credentials of a user
+type BasicAuthUserConfig struct {
+	// Username of the user, required
+	Username string `mapstructure:"username"`
+
+	// Password may be plaintext or a bcrypt/argon2id hash, required
+	Password string `mapstructure:"password"`
+
+	// PasswordFile is a file to read Password from, optional
+	PasswordFile string `mapstructure:"passwordFile"`
+}
+
+// Validate validates the BasicAuthConfig
+func (c *BasicAuthConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Users) == 0 {
+		return fmt.Errorf("missing basicAuth.users")
+	}
+	names := map[string]bool{}
+	for _, u := range c.Users {
+		if len(u.Username) == 0 {
+			return fmt.Errorf("missing basicAuth.users.username")
+		}
+		if len(u.Password) == 0 {
+			return fmt.Errorf("missing basicAuth.users(%s).password", u.Username)
+		}
+		if err := validateSecret(u.Password); err != nil {
+			return fmt.Errorf("invalid basicAuth.users(%s).password: %s", u.Username, err)
+		}
+		if names[u.Username] {
+			return fmt.Errorf("duplicate basicAuth.users.username %s", u.Username)
+		}
+		names[u.Username] = true
+	}
+
+	return nil
+}
+
+// hasBasicAuth returns whether the request of c carries Basic credentials
+func hasBasicAuth(c echo.Context) bool {
+	_, _, ok := c.Request().BasicAuth()
+	return ok
+}
+
+// webAuthMiddlewares returns the middlewares protecting a webserver route
+// using the header auth config and/or Basic auth config basic.
+// If both are enabled either of them has to succeed.
+func (s *Server) webAuthMiddlewares(auth *AuthConfig, validator middleware.KeyAuthValidator, basic *BasicAuthConfig) []echo.MiddlewareFunc {
+	middlewares := []echo.MiddlewareFunc{}
+
+	if basic.Enabled {
+		middlewares = append(middlewares, middleware.BasicAuthWithConfig(
+			middleware.BasicAuthConfig{
+				// leave requests without Basic credentials to the header auth
+				Skipper: func(c echo.Context) bool {
+					return auth.Enabled && !hasBasicAuth(c)
+				},
+				Realm: basic.Realm,
+				Validator: func(username, password string, c echo.Context) (bool, error) {
+					return s.authenticateBasic(basic, username, password), nil
+				},
+			},
+		))
+	}
+	if auth.Enabled {
+		config := middleware.KeyAuthConfig{
+			// Basic credentials have been checked already
+			Skipper: func(c echo.Context) bool {
+				return basic.Enabled && hasBasicAuth(c)
+			},
+			KeyLookup:  "header:" + auth.Header,
+			AuthScheme: auth.Scheme,
+			Validator:  validator,
+		}
+		if basic.Enabled {
+			// let browsers prompt for Basic credentials
+			config.ErrorHandler = func(err error, c echo.Context) error {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate,
+					fmt.Sprintf("basic realm=%q", basic.Realm))
+				return echo.ErrUnauthorized
+			}
+		}
+		middlewares = append(middlewares, middleware.KeyAuthWithConfig(config))
+	}
+
+	return middlewares
+}
+
+// authenticateBasic returns whether username and password match any user
+func (s *Server) authenticateBasic(config *BasicAuthConfig, username, password string) bool {
+	for _, u := range config.Users {
+		if subtle.ConstantTimeCompare([]byte(username), []byte(u.Username)) == 1 &&
+			compareSecret(password, s.secret(u.Password)) {
+			return true
+		}
+	}
+
+	return false
+}
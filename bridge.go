@@ -0,0 +1,386 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/knxnet"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// BridgeConfig holds the config of bridging telegrams to a second KNX gateway,
+// knxrpc acts as a line coupler between both gateways
+type BridgeConfig struct {
+	// Enabled connects to the bridged gateway and forwards telegrams by Rules
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// GatewayHost is the Host or IP address of the bridged KNX gateway, required if enabled
+	GatewayHost string `mapstructure:"gatewayHost"`
+
+	// GatewayPort is the port of the bridged KNX gateway, defaults to 3671
+	GatewayPort int `mapstructure:"gatewayPort" default:"3671"`
+
+	// SendLocalAddress sends the local address when establishing the tunnel (breaks NAT)
+	SendLocalAddress bool `mapstructure:"sendLocalAddress" default:"false"`
+
+	// UseTCP establishes the tunnel using tcp instead of udp
+	UseTCP bool `mapstructure:"useTCP" default:"false"`
+
+	// QueueSize is the amount of telegrams per direction waiting to be sent,
+	// further telegrams are dropped
+	QueueSize int `mapstructure:"queueSize" default:"100"`
+
+	// Rules is the filter table of forwarded group addresses,
+	// the first matching rule of a direction applies
+	Rules []BridgeRuleConfig `mapstructure:"rules"`
+}
+
+// BridgeRuleConfig holds a rule of the bridge filter table
+type BridgeRuleConfig struct {
+	// Direction to forward, optional (defaults to both)
+	// oneof: out (knx.gatewayHost to knx.bridge.gatewayHost)|in|both
+	Direction string `mapstructure:"direction"`
+
+	// GroupAddresses lists group address patterns to forward, required
+	// valid format: 1/2/3, 1/2/*, 1/2/10-20
+	GroupAddresses []string `mapstructure:"groupAddresses"`
+
+	// Rewrite replaces levels of forwarded group addresses, optional (requires direction in or out)
+	// each level is either a number replacing it or * keeping it, e.g.: 5/*/*
+	Rewrite string `mapstructure:"rewrite"`
+}
+
+// Validate validates the BridgeConfig
+func (c *BridgeConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.GatewayHost) == 0 {
+		return fmt.Errorf("missing knx.bridge.gatewayHost")
+	}
+	if c.GatewayPort == 0 {
+		return fmt.Errorf("missing knx.bridge.gatewayPort")
+	}
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("invalid knx.bridge.queueSize")
+	}
+	if _, _, err := parseBridgeRules(c.Rules); err != nil {
+		return fmt.Errorf("knx.bridge.%s", err)
+	}
+
+	return nil
+}
+
+// bridgeRule is the parsed form of a BridgeRuleConfig
+type bridgeRule struct {
+	patterns []groupAddressPattern
+	// rewrite replaces the levels of the group address, nil to keep it
+	rewrite *groupAddressPattern
+}
+
+// parseBridgeRules returns the rules of both directions or error
+func parseBridgeRules(configs []BridgeRuleConfig) (out, in []bridgeRule, err error) {
+	if len(configs) == 0 {
+		return nil, nil, errors.New("rules: missing rules")
+	}
+
+	for i, config := range configs {
+		rule := bridgeRule{}
+		if len(config.GroupAddresses) == 0 {
+			return nil, nil, fmt.Errorf("rules[%d]: missing groupAddresses", i)
+		}
+		rule.patterns, err = parseGroupAddressPatterns(config.GroupAddresses)
+		if err != nil {
+			return nil, nil, fmt.Errorf("rules[%d]: groupAddresses: %s", i, err)
+		}
+
+		if len(config.Rewrite) > 0 {
+			rewrite, err := parseGroupAddressPattern(config.Rewrite)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rules[%d]: rewrite: %s", i, err)
+			}
+			for level, r := range rewrite {
+				if r.lo != r.hi && (r.lo != 0 || r.hi != groupAddressLevelMax[level]) {
+					return nil, nil, fmt.Errorf("rules[%d]: rewrite: level %d must be a number or *", i, level)
+				}
+			}
+			rule.rewrite = &rewrite
+		}
+
+		switch strings.ToLower(config.Direction) {
+		case "out":
+			out = append(out, rule)
+		case "in":
+			in = append(in, rule)
+		case "", "both":
+			if rule.rewrite != nil {
+				return nil, nil, fmt.Errorf("rules[%d]: rewrite requires direction in or out", i)
+			}
+			out = append(out, rule)
+			in = append(in, rule)
+		default:
+			return nil, nil, fmt.Errorf("rules[%d]: unsupported direction: %s", i, config.Direction)
+		}
+	}
+
+	return out, in, nil
+}
+
+// route returns the group address to forward ga as by the first matching rule
+func route(rules []bridgeRule, ga cemi.GroupAddr) (cemi.GroupAddr, bool) {
+	for _, rule := range rules {
+		if !matchGroupAddressPatterns(rule.patterns, ga) {
+			continue
+		}
+		if rule.rewrite == nil {
+			return ga, true
+		}
+
+		levels := [3]int{
+			int(ga>>11) & 0x1f,
+			int(ga>>8) & 0x7,
+			int(ga) & 0xff,
+		}
+		for i, r := range rule.rewrite {
+			if r.lo == r.hi {
+				levels[i] = r.lo
+			}
+		}
+
+		return cemi.NewGroupAddr3(uint8(levels[0]), uint8(levels[1]), uint8(levels[2])), true
+	}
+
+	return 0, false
+}
+
+// bridge forwards group telegrams between knx.gatewayHost and knx.bridge.gatewayHost
+type bridge struct {
+	config *BridgeConfig
+	log    *zerolog.Logger
+
+	// out and in are the rules by direction
+	out []bridgeRule
+	in  []bridgeRule
+
+	// tunnel is the connected tunnel of the bridged gateway
+	tunnel *knx.Tunnel
+	// relay forwards tunnel to the gateway if required
+	relay relay
+
+	// toBridge and toPrimary queue the telegrams to send
+	toBridge  chan cemi.LData
+	toPrimary chan cemi.LData
+
+	// repeats detects repeated telegrams received from the bridged gateway
+	repeats *repeatDetector
+
+	// forwarded counts forwarded telegrams by direction, nil if disabled
+	forwarded otelmetric.Int64Counter
+}
+
+// setupBridge sets up s.bridge if enabled, requires s.repeats
+func (s *Server) setupBridge() (err error) {
+	config := &s.config.KNX.Bridge
+	if !config.Enabled {
+		return nil
+	}
+
+	b := &bridge{
+		config:    config,
+		log:       s.log,
+		toBridge:  make(chan cemi.LData, config.QueueSize),
+		toPrimary: make(chan cemi.LData, config.QueueSize),
+		repeats: &repeatDetector{
+			config:  &s.config.KNX.Deduplication,
+			seen:    map[repeatKey]time.Time{},
+			repeats: s.repeats.repeats,
+		},
+	}
+	b.out, b.in, err = parseBridgeRules(config.Rules)
+	if err != nil {
+		return fmt.Errorf("knx.bridge.%s", err)
+	}
+
+	if s.meterProvider != nil {
+		b.forwarded, err = s.meterProvider.Meter("github.com/choopm/knxrpc").Int64Counter(
+			"knxrpc.bridge.forwarded",
+			otelmetric.WithDescription("Telegrams forwarded by the bridge, by direction"),
+			otelmetric.WithUnit("{telegram}"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	s.bridge = b
+
+	return nil
+}
+
+// connectBridge connects the tunnel of the bridged gateway
+func (s *Server) connectBridge() (err error) {
+	// relay IPv6 like knx.gatewayHost
+	hostPort, relay, err := relayGateway(&KNXConfig{
+		GatwewayHost:     s.bridge.config.GatewayHost,
+		GatwewayPort:     s.bridge.config.GatewayPort,
+		SendLocalAddress: s.bridge.config.SendLocalAddress,
+		UseTCP:           s.bridge.config.UseTCP,
+	})
+	if err != nil {
+		return fmt.Errorf("connect bridge tunnel: %s", err)
+	}
+	s.bridge.relay = relay
+
+	s.bridge.tunnel, err = knx.NewTunnel(hostPort, knxnet.TunnelLayerData, knx.TunnelConfig{
+		ResendInterval:    knx.DefaultTunnelConfig.ResendInterval,
+		HeartbeatInterval: knx.DefaultTunnelConfig.HeartbeatInterval,
+		ResponseTimeout:   s.config.KNX.Timeout,
+		SendLocalAddress:  s.bridge.config.SendLocalAddress,
+		UseTCP:            s.bridge.config.UseTCP,
+	})
+	if err != nil {
+		if relay != nil {
+			relay.Close() // nolint:errcheck
+		}
+		return fmt.Errorf("connect bridge tunnel: %s", err)
+	}
+
+	return nil
+}
+
+// forwardOut queues ldata received from or sent to knx.gatewayHost
+// for the bridged gateway if matched by a rule
+func (b *bridge) forwardOut(ldata *cemi.LData) {
+	if b == nil {
+		return
+	}
+
+	b.enqueue(b.toBridge, b.out, ldata)
+}
+
+// enqueue queues ldata to queue if matched by rules
+func (b *bridge) enqueue(queue chan cemi.LData, rules []bridgeRule, ldata *cemi.LData) {
+	ga, ok := route(rules, cemi.GroupAddr(ldata.Destination))
+	if !ok {
+		return
+	}
+	// the routing counter limits loops, 7 is never decremented
+	hops := uint8(ldata.Control2>>4) & 7
+	if hops == 0 {
+		return
+	}
+	if hops < 7 {
+		hops--
+	}
+
+	forward := *ldata
+	forward.Control1 = ldata.Control1&(cemi.Control1StdFrame|cemi.Control1NoSysBroadcast|cemi.Control1Prio(cemi.PrioLow)) |
+		cemi.Control1NoRepeat | cemi.Control1WantAck
+	forward.Control2 = cemi.Control2GroupAddr | cemi.Control2Hops(hops)
+	forward.Destination = uint16(ga)
+
+	select {
+	case queue <- forward:
+	default:
+		b.log.Warn().
+			Str("group-address", ga.String()).
+			Msg("bridge queue is full, dropping telegram")
+	}
+}
+
+// bridgeReader forwards telegrams of the bridged gateway matched by a rule
+// to knx.gatewayHost until ctx is done or error
+func (s *Server) bridgeReader(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case msg, ok := <-s.bridge.tunnel.Inbound():
+			if !ok {
+				return errors.New("knx bridge tunnel inbound closed")
+			}
+			ind, ok := msg.(*cemi.LDataInd)
+			if !ok || !ind.Control2.IsGroupAddr() {
+				// confirmations and management frames stay on their line
+				continue
+			}
+			app, ok := ind.Data.(*cemi.AppData)
+			if !ok || !app.Command.IsGroupCommand() {
+				continue
+			}
+			if s.bridge.repeats.check(ind, app) {
+				continue
+			}
+			s.bridge.enqueue(s.bridge.toPrimary, s.bridge.in, &ind.LData)
+		}
+	}
+}
+
+// bridgeSender sends the queued telegrams of the bridge until ctx is done.
+// Telegrams sent to knx.gatewayHost are dispatched like published events.
+func (s *Server) bridgeSender(ctx context.Context) error {
+	for {
+		var err error
+		var ldata cemi.LData
+		direction := "out"
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ldata = <-s.bridge.toBridge:
+			err = s.bridge.tunnel.Send(&cemi.LDataReq{LData: ldata})
+
+		case ldata = <-s.bridge.toPrimary:
+			direction = "in"
+			err = s.tunnel.Send(&cemi.LDataReq{LData: ldata})
+			if err == nil {
+				app := ldata.Data.(*cemi.AppData)
+				err = s.dispatchEvent(&knx.GroupEvent{
+					Command:     knx.GroupCommand(app.Command),
+					Source:      ldata.Source,
+					Destination: cemi.GroupAddr(ldata.Destination),
+					Data:        app.Data,
+				})
+			}
+		}
+		if err != nil {
+			s.log.Warn().
+				Err(err).
+				Str("direction", direction).
+				Str("group-address", cemi.GroupAddr(ldata.Destination).String()).
+				Msg("unable to forward bridged telegram")
+			continue
+		}
+
+		if s.bridge.forwarded != nil {
+			s.bridge.forwarded.Add(ctx, 1, otelmetric.WithAttributes(
+				attribute.String("direction", direction),
+			))
+		}
+	}
+}
@@ -25,11 +25,40 @@ import (
 	"strconv"
 
 	"connectrpc.com/connect"
+	deviceV1Connect "github.com/choopm/knxrpc/knx/device/v1/v1connect"
 	"github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
 )
 
 // NewClient returns a fresh GroupAddressServiceClient from config
 func NewClient(config ClientConfig, opts ...connect.ClientOption) (v1connect.GroupAddressServiceClient, error) {
+	hclient, baseURL, opts := newHTTPClient(config, opts...)
+
+	client := v1connect.NewGroupAddressServiceClient(
+		hclient,
+		baseURL,
+		opts...,
+	)
+
+	return client, nil
+}
+
+// NewDeviceClient returns a fresh DeviceServiceClient from config
+func NewDeviceClient(config ClientConfig, opts ...connect.ClientOption) (deviceV1Connect.DeviceServiceClient, error) {
+	hclient, baseURL, opts := newHTTPClient(config, opts...)
+
+	client := deviceV1Connect.NewDeviceServiceClient(
+		hclient,
+		baseURL,
+		opts...,
+	)
+
+	return client, nil
+}
+
+// newHTTPClient returns the *http.Client, base URL and client options
+// to construct any service client from config.
+func newHTTPClient(config ClientConfig, opts ...connect.ClientOption) (*http.Client, string, []connect.ClientOption) {
+	opts = append(compressionClientOptions(config.Compression), opts...)
 	if config.Auth.Enabled {
 		opts = append(opts, connect.WithInterceptors(
 			NewAuthInterceptor(config.Auth),
@@ -37,6 +66,11 @@ func NewClient(config ClientConfig, opts ...connect.ClientOption) (v1connect.Gro
 	}
 
 	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.HTTP2 && !config.UseTLS {
+		// HTTP/2 with prior knowledge instead of HTTP/1
+		transport.Protocols = &http.Protocols{}
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
 	if config.InsecureTLS {
 		if transport.TLSClientConfig == nil {
 			transport.TLSClientConfig = &tls.Config{}
@@ -46,21 +80,23 @@ func NewClient(config ClientConfig, opts ...connect.ClientOption) (v1connect.Gro
 	hclient := &http.Client{
 		Transport: transport,
 	}
+	if config.Auth.HMAC.Enabled {
+		hclient.Transport = &hmacTransport{
+			config: &config.Auth.HMAC,
+			next:   transport,
+		}
+	}
 
 	scheme := "http://"
 	if config.UseTLS {
 		scheme = "https://"
 	}
 
-	client := v1connect.NewGroupAddressServiceClient(
-		hclient,
-		fmt.Sprintf("%s%s", scheme, net.JoinHostPort(
-			config.Host,
-			strconv.Itoa(config.Port))),
-		opts...,
-	)
+	baseURL := fmt.Sprintf("%s%s", scheme, net.JoinHostPort(
+		config.Host,
+		strconv.Itoa(config.Port)))
 
-	return client, nil
+	return hclient, baseURL, opts
 }
 
 // NewAuthInterceptor returns a [headerInterceptor] to add authentication
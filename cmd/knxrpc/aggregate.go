@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// aggregateCommand returns a *cobra.Command to aggregate recorded values from a ConfigProvider
+func aggregateCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	fls := pflag.NewFlagSet("aggregate", pflag.ContinueOnError)
+	dptName := fls.String("dpt", "",
+		"datapoint type to decode values with, e.g.: 9.001")
+	from := fls.String("from", "",
+		"optional RFC3339 start time, e.g.: 2024-01-02T15:04:05Z")
+	to := fls.String("to", "",
+		"optional RFC3339 end time, e.g.: 2024-01-02T15:04:05Z")
+	bucket := fls.String("bucket", "",
+		"optional bucket size, e.g.: 1h")
+
+	cmd := &cobra.Command{
+		Use:   "aggregate <1/2/3>",
+		Short: "aggregate - connects to knxrpc and returns statistics of recorded values",
+		Long:  "requires rpc.history to be enabled",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.Aggregate(cmd.Context(),
+				connect.NewRequest(&v1.AggregateRequest{
+					GroupAddress: args[0],
+					Dpt:          *dptName,
+					From:         *from,
+					To:           *to,
+					Bucket:       *bucket,
+				}))
+			if err != nil {
+				return err
+			}
+
+			for _, b := range res.Msg.Buckets {
+				logger.Info().
+					Str("start", b.Start).
+					Str("end", b.End).
+					Uint32("count", b.Count).
+					Float64("min", b.Min).
+					Float64("max", b.Max).
+					Float64("avg", b.Avg).
+					Float64("last", b.Last).
+					Msg("bucket")
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
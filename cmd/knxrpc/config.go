@@ -0,0 +1,162 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/choopm/stdfx/loggingfx/zerologfx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configCommand returns the stdfx config *cobra.Command extended by
+// a --check mode to dry-run the config before deploying it
+func configCommand(
+	log *slog.Logger,
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	fls := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	check := fls.Bool("check", false,
+		"validate the full config")
+	tunnel := fls.Bool("tunnel", false,
+		"with --check, connect a short-lived tunnel to the gateway")
+	rpc := fls.Bool("rpc", false,
+		"with --check, start the server briefly and call it using the knxrpc section")
+
+	cmd := stdfx.ConfigCommand(log, configProvider)
+	cmd.Long = "use --check to validate the config and optionally test connectivity"
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !*check {
+			if *tunnel || *rpc {
+				return fmt.Errorf("--tunnel and --rpc require --check")
+			}
+			return cmd.Help()
+		}
+
+		// reuse the strict parsing and validation of the validate subcommand
+		validate, _, err := cmd.Find([]string{"validate"})
+		if err != nil {
+			return err
+		}
+		if err := validate.RunE(validate, nil); err != nil {
+			return err
+		}
+
+		cfg, err := configProvider.Config()
+		if err != nil {
+			return err
+		}
+		if *tunnel {
+			channel, err := knxrpc.ProbeTunnel(&cfg.KNX)
+			if err != nil {
+				return fmt.Errorf("tunnel: %s", err)
+			}
+			log.Info("tunnel ok",
+				slog.String("gateway", fmt.Sprintf("%s:%d", cfg.KNX.GatwewayHost, cfg.KNX.GatwewayPort)),
+				slog.Int("channel", int(channel)))
+		}
+		if *rpc {
+			if err := checkSelfCall(cmd.Context(), cfg); err != nil {
+				return fmt.Errorf("rpc: %s", err)
+			}
+			log.Info("rpc ok",
+				slog.String("host", cfg.Client.Host),
+				slog.Int("port", cfg.Client.Port))
+		}
+
+		return nil
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
+
+// checkSelfCall starts a server from cfg, lists its group addresses using
+// the client config and stops it again.
+func checkSelfCall(ctx context.Context, cfg *knxrpc.Config) error {
+	if !cfg.RPC.Webserver.Enabled {
+		return fmt.Errorf("requires rpc.webserver.enabled")
+	}
+	if err := cfg.Client.Validate(); err != nil {
+		return err
+	}
+
+	// do not call a server which is running already
+	l, err := net.Listen("tcp", net.JoinHostPort(cfg.RPC.Webserver.Host,
+		strconv.Itoa(cfg.RPC.Webserver.Port)))
+	if err != nil {
+		return err
+	}
+	l.Close() // nolint:errcheck
+
+	logger, err := zerologfx.New(cfg.Log)
+	if err != nil {
+		return err
+	}
+	server, err := knxrpc.New(cfg, logger)
+	if err != nil {
+		return err
+	}
+	client, err := knxrpc.NewClient(cfg.Client)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(ctx)
+	}()
+
+	// retry until the webserver is listening
+	deadline := time.After(cfg.KNX.Timeout + 5*time.Second)
+	for {
+		_, err = client.ListGroupAddresses(ctx,
+			connect.NewRequest(&v1.ListGroupAddressesRequest{}))
+		if connect.CodeOf(err) != connect.CodeUnavailable {
+			break
+		}
+
+		select {
+		case err := <-done:
+			if err == nil {
+				err = fmt.Errorf("server stopped")
+			}
+			return err
+		case <-deadline:
+			return err
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	return err
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	deviceV1 "github.com/choopm/knxrpc/knx/device/v1"
+	deviceV1Connect "github.com/choopm/knxrpc/knx/device/v1/v1connect"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/choopm/stdfx/loggingfx/zerologfx"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// deviceCommand returns a *cobra.Command for device management from a ConfigProvider
+func deviceCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "device",
+		Short: "device - connects to knxrpc and manages devices",
+		Long:  "commissioning tasks using individual addresses",
+	}
+
+	cmd.AddCommand(
+		deviceDescriptorCommand(configProvider),
+		deviceRestartCommand(configProvider),
+		deviceAddressCommand(configProvider),
+	)
+
+	return cmd
+}
+
+// deviceDescriptorCommand returns a *cobra.Command to read a device descriptor
+func deviceDescriptorCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "descriptor <1.2.3>",
+		Short: "descriptor - reads the device descriptor (mask version)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newDeviceClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.ReadDeviceDescriptor(cmd.Context(),
+				connect.NewRequest(&deviceV1.ReadDeviceDescriptorRequest{
+					IndividualAddress: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("individual-address", res.Msg.IndividualAddress).
+				Str("mask-version", fmt.Sprintf("%04X", res.Msg.MaskVersion)).
+				Msg("device descriptor")
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// deviceRestartCommand returns a *cobra.Command to restart a device
+func deviceRestartCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart <1.2.3>",
+		Short: "restart - sends a basic restart to a device",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newDeviceClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.RestartDevice(cmd.Context(),
+				connect.NewRequest(&deviceV1.RestartDeviceRequest{
+					IndividualAddress: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("individual-address", args[0]).
+				Msg("device restarted")
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// deviceAddressCommand returns a *cobra.Command to read/write individual addresses
+func deviceAddressCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "address",
+		Short: "address - reads or writes individual addresses in programming mode",
+	}
+
+	fls := pflag.NewFlagSet("read", pflag.ContinueOnError)
+	forDuration := fls.String("for", "",
+		"optional duration to collect responses, e.g.: 3s")
+
+	readCmd := &cobra.Command{
+		Use:   "read",
+		Short: "read - lists devices in programming mode",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newDeviceClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.ReadIndividualAddresses(cmd.Context(),
+				connect.NewRequest(&deviceV1.ReadIndividualAddressesRequest{
+					For: *forDuration,
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Strs("individual-addresses", res.Msg.IndividualAddresses).
+				Msg("devices in programming mode")
+
+			return nil
+		},
+	}
+	readCmd.Flags().AddFlagSet(fls)
+
+	writeCmd := &cobra.Command{
+		Use:   "write <1.2.3>",
+		Short: "write - assigns an individual address to the device in programming mode",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newDeviceClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.WriteIndividualAddress(cmd.Context(),
+				connect.NewRequest(&deviceV1.WriteIndividualAddressRequest{
+					IndividualAddress: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("individual-address", args[0]).
+				Msg("individual address written")
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(readCmd, writeCmd)
+
+	return cmd
+}
+
+// newDeviceClient returns a DeviceServiceClient and logger from a ConfigProvider
+func newDeviceClient(
+	configProvider configfx.Provider[knxrpc.Config],
+) (deviceV1Connect.DeviceServiceClient, *zerolog.Logger, error) {
+	// fetch the config
+	cfg, err := configProvider.Config()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// rebuild logger and make it global
+	logger, err := zerologfx.New(cfg.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Logger = *logger
+
+	// create the client instance
+	client, err := knxrpc.NewDeviceClient(cfg.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, logger, nil
+}
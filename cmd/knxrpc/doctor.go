@@ -0,0 +1,99 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/choopm/knxrpc"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// diagnosisColors maps a status to its ANSI color
+var diagnosisColors = map[knxrpc.DiagnosisStatus]string{
+	knxrpc.DiagnosisOK:      "\033[32m",
+	knxrpc.DiagnosisWarning: "\033[33m",
+	knxrpc.DiagnosisFailed:  "\033[31m",
+	knxrpc.DiagnosisSkipped: "\033[90m",
+}
+
+// doctorCommand returns a *cobra.Command to diagnose the setup from a ConfigProvider
+func doctorCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	fls := pflag.NewFlagSet("doctor", pflag.ContinueOnError)
+	noColor := fls.Bool("no-color", false,
+		"disable colored output, also disabled by NO_COLOR or when not a terminal")
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "doctor - checks the gateway, tunnel, webserver and auth setup",
+		Long:  "fails if any check failed, a running server is used for the auth round-trip",
+		Args:  cobra.NoArgs,
+		// failed checks are no usage errors
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// fetch the config
+			cfg, err := configProvider.Config()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			color := !*noColor && len(os.Getenv("NO_COLOR")) == 0 && isTerminal(out)
+
+			failed := 0
+			for _, d := range knxrpc.Diagnose(cmd.Context(), cfg) {
+				status := fmt.Sprintf("%-4s", d.Status)
+				if color {
+					status = diagnosisColors[d.Status] + status + "\033[0m"
+				}
+				fmt.Fprintf(out, "[%s] %-10s %s\n", status, d.Name, d.Detail)
+
+				if d.Status == knxrpc.DiagnosisFailed {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d check(s) failed", failed)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
+
+// isTerminal returns whether w is a character device
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
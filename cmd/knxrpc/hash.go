@@ -0,0 +1,67 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/choopm/knxrpc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// hashCommand returns a *cobra.Command to hash secrets for the config
+func hashCommand() *cobra.Command {
+	fls := pflag.NewFlagSet("hash", pflag.ContinueOnError)
+	algorithm := fls.String("algorithm", "bcrypt",
+		"hash algorithm, oneof: bcrypt|argon2id")
+
+	cmd := &cobra.Command{
+		Use:   "hash [secret]",
+		Short: "hash - hashes a secret to be used as secretKey in the config",
+		Long:  "reads the secret from stdin if omitted",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			secret := ""
+			if len(args) > 0 {
+				secret = args[0]
+			} else {
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && len(line) == 0 {
+					return fmt.Errorf("reading secret: %v", err)
+				}
+				secret = strings.TrimRight(line, "\r\n")
+			}
+			if len(secret) == 0 {
+				return fmt.Errorf("empty secret")
+			}
+
+			hash, err := knxrpc.HashSecret(secret, *algorithm)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), hash)
+
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/choopm/knxrpc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/vapourismo/knx-go/knx"
+)
+
+// initTemplate is the commented starter config written by initCommand
+var initTemplate = template.Must(template.New("knxrpc.yaml").
+	Funcs(template.FuncMap{"quote": strconv.Quote}).
+	Parse(`# generated by "knxrpc init", dry-run it using "knxrpc config --check --tunnel",
+# see cmd/knxrpc/knxrpc.yaml in the repository for all settings
+log:
+  format: text # json, text, color
+  level: info # trace, debug, info, warn, error
+  output: stdout # stdout, stderr, <filename>
+
+knx:
+  # KNXnet/IP gateway to tunnel through
+  gatewayHost: {{ quote .GatewayHost }}
+  gatewayPort: {{ .GatewayPort }}
+  timeout: 10s
+  # tunnel using TCP instead of UDP, requires a KNXnet/IP 2.0 gateway
+  useTCP: {{ .UseTCP }}
+  # groupAddresses names group addresses and sets their datapoint type
+  groupAddresses: []
+  # - address: 1/2/3
+  #   name: Living room temperature
+  #   dpt: "9.001"
+
+rpc:
+  auth:
+    enabled: {{ .Auth }}
+    header: Authorization
+    scheme: Bearer
+    # hash of knxrpc.auth.secretKey, create new ones using "knxrpc hash"
+    secretKey: {{ quote .SecretKeyHash }}
+
+  webserver:
+    enabled: {{ .Webserver }}
+    host: {{ quote .Host }}
+    port: {{ .Port }}
+    logRequests: false
+    swagger:
+      enabled: {{ .Swagger }}
+      path: /swagger
+    metrics:
+      enabled: {{ .Metrics }}
+      path: /metrics
+    ui:
+      enabled: {{ .UI }}
+      path: /ui
+
+# for subscribe/publish subcommands
+knxrpc:
+  host: {{ quote .ClientHost }}
+  port: {{ .Port }}
+  # connect using TLS, e.g. behind a reverse proxy
+  useTLS: {{ .UseTLS }}
+  insecureTLS: {{ .InsecureTLS }}
+  auth:
+    enabled: {{ .Auth }}
+    header: Authorization
+    scheme: Bearer
+    secretKey: {{ quote .SecretKey }}
+`))
+
+// initConfig holds the answers rendered into initTemplate
+type initConfig struct {
+	GatewayHost   string
+	GatewayPort   int
+	UseTCP        bool
+	Auth          bool
+	SecretKey     string
+	SecretKeyHash string
+	Webserver     bool
+	Host          string
+	Port          int
+	Swagger       bool
+	Metrics       bool
+	UI            bool
+	ClientHost    string
+	UseTLS        bool
+	InsecureTLS   bool
+}
+
+// initCommand returns a *cobra.Command to write a starter config
+func initCommand() *cobra.Command {
+	fls := pflag.NewFlagSet("init", pflag.ContinueOnError)
+	output := fls.StringP("output", "o", "knxrpc.yaml",
+		"file to write the config to")
+	force := fls.Bool("force", false,
+		"overwrite an existing file")
+	nonInteractive := fls.BoolP("yes", "y", false,
+		"do not ask, use the flags and defaults")
+	discover := fls.Duration("discover", 3*time.Second,
+		"time to search for gateways using multicast, 0 disables it")
+	cfg := initConfig{}
+	fls.StringVar(&cfg.GatewayHost, "gateway", "",
+		"gateway host, defaults to the first discovered gateway")
+	fls.IntVar(&cfg.GatewayPort, "gateway-port", 3671,
+		"gateway port")
+	fls.BoolVar(&cfg.UseTCP, "use-tcp", false,
+		"tunnel using TCP instead of UDP")
+	fls.BoolVar(&cfg.Auth, "auth", true,
+		"require an API key, a random key is generated")
+	fls.BoolVar(&cfg.Webserver, "webserver", true,
+		"start the webserver")
+	fls.StringVar(&cfg.Host, "host", "127.0.0.1",
+		"webserver listening host, use 0.0.0.0 for all interfaces")
+	fls.IntVar(&cfg.Port, "port", 8080,
+		"webserver listening port")
+	fls.BoolVar(&cfg.Swagger, "swagger", true,
+		"serve the swagger UI")
+	fls.BoolVar(&cfg.Metrics, "metrics", false,
+		"serve prometheus metrics")
+	fls.BoolVar(&cfg.UI, "ui", false,
+		"serve the group monitor UI")
+	fls.BoolVar(&cfg.UseTLS, "tls", false,
+		"clients connect using TLS, e.g. behind a reverse proxy")
+	fls.BoolVar(&cfg.InsecureTLS, "insecure-tls", false,
+		"clients skip verifying the TLS certificate")
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "init - writes a commented starter config",
+		Long:  "asks for gateway, auth, TLS and webserver options unless --yes is given",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(*output); err == nil && !*force {
+				return fmt.Errorf("%s exists, use --force to overwrite it", *output)
+			}
+
+			p := &prompter{
+				in:  bufio.NewReader(cmd.InOrStdin()),
+				out: cmd.OutOrStdout(),
+				ask: !*nonInteractive,
+			}
+			// only ask for settings not given as flags
+			changed := cmd.Flags().Changed
+
+			// gateway
+			if !changed("gateway") {
+				gateways := discoverGateways(p.out, *discover)
+				if len(gateways) > 0 {
+					cfg.GatewayHost = gateways[0]
+				}
+				if len(gateways) > 1 && p.ask {
+					for i, gw := range gateways {
+						fmt.Fprintf(p.out, "  %d) %s\n", i+1, gw)
+					}
+					i := p.int("Use gateway number", 1)
+					if i < 1 || i > len(gateways) {
+						return fmt.Errorf("no gateway number %d", i)
+					}
+					cfg.GatewayHost = gateways[i-1]
+				}
+				cfg.GatewayHost = p.string("Gateway host", cfg.GatewayHost)
+			}
+			if len(cfg.GatewayHost) == 0 {
+				return errors.New("missing gateway host, use --gateway")
+			}
+			if !changed("gateway-port") {
+				cfg.GatewayPort = p.int("Gateway port", cfg.GatewayPort)
+			}
+			if !changed("use-tcp") {
+				cfg.UseTCP = p.bool("Tunnel using TCP (KNXnet/IP 2.0)", cfg.UseTCP)
+			}
+
+			// webserver
+			if !changed("webserver") {
+				cfg.Webserver = p.bool("Start the webserver", cfg.Webserver)
+			}
+			if cfg.Webserver {
+				if !changed("host") {
+					cfg.Host = p.string("Webserver listening host", cfg.Host)
+				}
+				if !changed("port") {
+					cfg.Port = p.int("Webserver listening port", cfg.Port)
+				}
+				if !changed("swagger") {
+					cfg.Swagger = p.bool("Serve the swagger UI", cfg.Swagger)
+				}
+				if !changed("metrics") {
+					cfg.Metrics = p.bool("Serve prometheus metrics", cfg.Metrics)
+				}
+				if !changed("ui") {
+					cfg.UI = p.bool("Serve the group monitor UI", cfg.UI)
+				}
+			}
+
+			// auth and TLS
+			if !changed("auth") {
+				cfg.Auth = p.bool("Require an API key", cfg.Auth)
+			}
+			if !changed("tls") {
+				cfg.UseTLS = p.bool("Clients connect using TLS (reverse proxy)", cfg.UseTLS)
+			}
+			if cfg.UseTLS && !changed("insecure-tls") {
+				cfg.InsecureTLS = p.bool("Skip verifying the TLS certificate", cfg.InsecureTLS)
+			}
+
+			cfg.ClientHost = cfg.Host
+			if cfg.ClientHost == "0.0.0.0" || cfg.ClientHost == "::" {
+				cfg.ClientHost = "127.0.0.1"
+			}
+			if cfg.Auth {
+				key := make([]byte, 24)
+				if _, err := rand.Read(key); err != nil {
+					return err
+				}
+				cfg.SecretKey = hex.EncodeToString(key)
+				hash, err := knxrpc.HashSecret(cfg.SecretKey, "bcrypt")
+				if err != nil {
+					return err
+				}
+				cfg.SecretKeyHash = hash
+			}
+
+			// the file contains the plaintext key of the client section
+			f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			if err := initTemplate.Execute(f, cfg); err != nil {
+				f.Close() // nolint:errcheck
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(p.out, "wrote %s\n", *output)
+			if cfg.Auth {
+				fmt.Fprintf(p.out, "API key for clients: %s\n", cfg.SecretKey)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
+
+// discoverGateways returns the addresses of gateways answering a search
+// request within timeout
+func discoverGateways(out io.Writer, timeout time.Duration) []string {
+	if timeout <= 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "searching gateways for %s...\n", timeout)
+	results, err := knx.Discover("224.0.23.12:3671", timeout)
+	if err != nil {
+		fmt.Fprintf(out, "discovery failed: %s\n", err)
+		return nil
+	}
+
+	ret := []string{}
+	for _, res := range results {
+		addr := res.Control.Address.String()
+		fmt.Fprintf(out, "found %q at %s\n", res.DescriptionB.DeviceHardware.FriendlyName, addr)
+		ret = append(ret, addr)
+	}
+	if len(ret) == 0 {
+		fmt.Fprintln(out, "no gateways found")
+	}
+
+	return ret
+}
+
+// prompter asks questions using defaults if ask is false or on empty answers
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	ask bool
+}
+
+// string asks question and returns the answer or def
+func (p *prompter) string(question, def string) string {
+	if !p.ask {
+		return def
+	}
+
+	fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	line, _ := p.in.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return def
+	}
+
+	return line
+}
+
+// int asks question until answered by a number or empty
+func (p *prompter) int(question string, def int) int {
+	for {
+		s := p.string(question, strconv.Itoa(def))
+		i, err := strconv.Atoi(s)
+		if err == nil {
+			return i
+		}
+		fmt.Fprintf(p.out, "not a number: %s\n", s)
+	}
+}
+
+// bool asks a yes/no question until answered or empty
+func (p *prompter) bool(question string, def bool) bool {
+	answer := "y/N"
+	if def {
+		answer = "Y/n"
+	}
+	for {
+		switch strings.ToLower(p.string(question, answer)) {
+		case strings.ToLower(answer):
+			return def
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		fmt.Fprintln(p.out, "answer y or n")
+	}
+}
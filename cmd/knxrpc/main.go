@@ -21,24 +21,36 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"connectrpc.com/connect"
 	"go.uber.org/fx"
 
 	"github.com/choopm/knxrpc"
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
 	"github.com/choopm/stdfx"
 	"github.com/choopm/stdfx/configfx"
 	"github.com/choopm/stdfx/loggingfx/zerologfx"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // version is provided by `-ldflags "-X main.version=1.0.0"`
 var version string = "unknown"
 
 func main() {
+	knxrpc.Version = version
+
 	fx.New(
 		// logging
 		zerologfx.Module,
@@ -51,10 +63,21 @@ func main() {
 		// cobra commands
 		fx.Provide(
 			stdfx.AutoRegister(stdfx.VersionCommand(version)),
-			stdfx.AutoRegister(stdfx.ConfigCommand[knxrpc.Config]),
+			stdfx.AutoRegister(configCommand),
 			stdfx.AutoRegister(serverCommand),
 			stdfx.AutoRegister(subscribeCommand),
 			stdfx.AutoRegister(publishCommand),
+			stdfx.AutoRegister(deviceCommand),
+			stdfx.AutoRegister(scanCommand),
+			stdfx.AutoRegister(scheduledCommand),
+			stdfx.AutoRegister(subscribersCommand),
+			stdfx.AutoRegister(statusCommand),
+			stdfx.AutoRegister(transactionCommand),
+			stdfx.AutoRegister(aggregateCommand),
+			stdfx.AutoRegister(hashCommand),
+			stdfx.AutoRegister(doctorCommand),
+			stdfx.AutoRegister(initCommand),
+			stdfx.AutoRegister(serviceCommand),
 			stdfx.AutoCommand, // add registered commands to root
 		),
 
@@ -72,31 +95,117 @@ func serverCommand(
 		Use:   "server",
 		Short: "server - starts knxrpc",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// fetch the config
-			cfg, err := configProvider.Config()
-			if err != nil {
-				return err
-			}
+			return runServer(cmd.Context(), configProvider)
+		},
+	}
 
-			// rebuild logger and make it global
-			logger, err := zerologfx.New(cfg.Log)
-			if err != nil {
-				return err
-			}
-			log.Logger = *logger
+	return cmd
+}
 
-			// create knxrpc instance
-			server, err := knxrpc.New(cfg, logger)
-			if err != nil {
-				return err
-			}
+// runServer runs the server from a ConfigProvider until ctx is done
+func runServer(
+	ctx context.Context,
+	configProvider configfx.Provider[knxrpc.Config],
+) error {
+	// fetch the config
+	cfg, err := configProvider.Config()
+	if err != nil {
+		return err
+	}
 
-			// start knxrpc using context
-			return server.Start(cmd.Context())
-		},
+	// rebuild logger and make it global
+	logger, err := zerologfx.New(cfg.Log)
+	if err != nil {
+		return err
+	}
+	// the level is applied globally so that reloads may change it
+	zerolog.SetGlobalLevel(logger.GetLevel())
+	*logger = logger.Level(zerolog.TraceLevel)
+	log.Logger = *logger
+
+	// create knxrpc instance
+	server, err := knxrpc.New(cfg, logger)
+	if err != nil {
+		return err
 	}
 
-	return cmd
+	// reload on SIGHUP and config file changes if enabled
+	go reloadServer(ctx, configProvider, server, cfg.RPC.Reload.Watch)
+
+	// start knxrpc using context
+	return server.Start(ctx)
+}
+
+// reloadServer reloads the config of server on SIGHUP and if watch is
+// enabled whenever the config file changes until ctx is done.
+func reloadServer(
+	ctx context.Context,
+	configProvider configfx.Provider[knxrpc.Config],
+	server *knxrpc.Server,
+	watch bool,
+) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	// editors emit several events per save, reload once they settle
+	debounce := time.NewTimer(0)
+	<-debounce.C
+	defer debounce.Stop()
+
+	// watch the directory to notice files replaced by renames,
+	// viper.WatchConfig is not used as it races with reading the config
+	var events chan fsnotify.Event
+	if watch {
+		file := filepath.Clean(configProvider.Viper().ConfigFileUsed())
+		watcher, err := fsnotify.NewWatcher()
+		if err == nil {
+			defer watcher.Close() // nolint:errcheck
+			err = watcher.Add(filepath.Dir(file))
+		}
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("file", file).
+				Msg("unable to watch config file")
+		} else {
+			events = make(chan fsnotify.Event)
+			go func() {
+				for ev := range watcher.Events {
+					if filepath.Clean(ev.Name) != file {
+						continue
+					}
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-events:
+			debounce.Reset(250 * time.Millisecond)
+			continue
+		case <-hup:
+		case <-debounce.C:
+		}
+
+		cfg, err := configProvider.Config()
+		if err == nil {
+			err = server.Reload(cfg)
+		}
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("reloading config failed, keeping current config")
+		}
+	}
 }
 
 // subscribeCommand returns a *cobra.Command to start a subscriber from a ConfigProvider
@@ -106,6 +215,14 @@ func subscribeCommand(
 	fls := pflag.NewFlagSet("subscribe", pflag.ContinueOnError)
 	eventFilter := fls.String("event", "",
 		"optional filter for events, oneof: read|write|response")
+	fields := fls.StringSlice("fields", nil,
+		"optional message fields to receive, e.g.: group_address,value")
+	consumer := fls.String("consumer", "",
+		"optional consumer name to acknowledge messages and receive missed ones on reconnect")
+	replayLast := fls.Uint32("replay-last", 0,
+		"optional count of recent messages per group address to receive first")
+	replayWithin := fls.String("replay-within", "",
+		"optional duration of recent messages to receive first, e.g.: 10m")
 
 	cmd := &cobra.Command{
 		Use:   "subscribe [1/2/3]...",
@@ -148,18 +265,39 @@ func subscribeCommand(
 				Bool("auth", cfg.Client.Auth.Enabled).
 				Msg("waiting for messages")
 
+			// SubscribeAcked is a bidi stream requiring HTTP/2
+			if len(*consumer) > 0 {
+				cfg.Client.HTTP2 = true
+			}
+
 			// create the client instance
 			client, err := knxrpc.NewClient(cfg.Client)
 			if err != nil {
 				return err
 			}
 
+			// receive only the requested fields
+			var mask *fieldmaskpb.FieldMask
+			if len(*fields) > 0 {
+				mask = &fieldmaskpb.FieldMask{Paths: *fields}
+			}
+			req := &v1.SubscribeRequest{
+				GroupAddresses: args,
+				Event:          ev,
+				Fields:         mask,
+			}
+			if *replayLast > 0 || len(*replayWithin) > 0 {
+				req.ReplayLast = &v1.ReplayLast{
+					Count:  *replayLast,
+					Within: *replayWithin,
+				}
+			}
+			if len(*consumer) > 0 {
+				return subscribeAcked(cmd.Context(), client, *consumer, req, logger)
+			}
+
 			// connect stream using group addresses and events from args
-			stream, err := client.Subscribe(cmd.Context(),
-				connect.NewRequest(&v1.SubscribeRequest{
-					GroupAddresses: args,
-					Event:          ev,
-				}))
+			stream, err := client.Subscribe(cmd.Context(), connect.NewRequest(req))
 			if err != nil {
 				return err
 			}
@@ -168,13 +306,7 @@ func subscribeCommand(
 
 			// start receiver loop
 			for stream.Receive() {
-				res := stream.Msg()
-				logger.Info().
-					Str("group-address", res.GroupAddress).
-					Str("physical-address", res.PhysicalAddress).
-					Str("event", res.Event.String()).
-					Bytes("data", res.Data).
-					Msg("received message")
+				logMessage(logger, stream.Msg())
 			}
 			if err := stream.Err(); err != nil &&
 				!errors.Is(err, context.Canceled) {
@@ -189,6 +321,63 @@ func subscribeCommand(
 	return cmd
 }
 
+// subscribeAcked receives the messages of consumer using req for a new
+// consumer, each message is acknowledged once logged
+func subscribeAcked(
+	ctx context.Context,
+	client v1Connect.GroupAddressServiceClient,
+	consumer string,
+	req *v1.SubscribeRequest,
+	logger *zerolog.Logger,
+) error {
+	stream := client.SubscribeAcked(ctx)
+	// close stream on context.Done()
+	context.AfterFunc(ctx, func() { stream.CloseRequest() }) // nolint:errcheck
+
+	err := stream.Send(&v1.SubscribeAckedRequest{
+		Consumer:         consumer,
+		SubscribeRequest: req,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Receive()
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) ||
+				connect.CodeOf(err) == connect.CodeCanceled {
+				return nil
+			}
+			return err
+		}
+		if res.Redelivered {
+			logger.Warn().
+				Uint64("delivery-id", res.DeliveryId).
+				Msg("redelivered message")
+		}
+		logMessage(logger, res.Message)
+
+		err = stream.Send(&v1.SubscribeAckedRequest{
+			Acks: []uint64{res.DeliveryId},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// logMessage logs a received message
+func logMessage(logger *zerolog.Logger, res *v1.SubscribeResponse) {
+	logger.Info().
+		Str("group-address", res.GroupAddress).
+		Str("physical-address", res.PhysicalAddress).
+		Str("event", res.Event.String()).
+		Bytes("data", res.Data).
+		Str("value", res.Value).
+		Msg("received message")
+}
+
 // publishCommand returns a *cobra.Command to publish a message from a ConfigProvider
 func publishCommand(
 	configProvider configfx.Provider[knxrpc.Config],
@@ -198,6 +387,16 @@ func publishCommand(
 		"event to send, oneof: read|write|response")
 	physicalAddress := fls.String("from", "",
 		"optionial physical address, e.g.: 1.2.3")
+	idempotencyKey := fls.String("idempotency-key", "",
+		"optional key to deduplicate retries of this message")
+	delay := fls.String("delay", "",
+		"optional delay to publish later, e.g.: 3m")
+	at := fls.String("at", "",
+		"optional RFC3339 time to publish at, e.g.: 2024-01-02T15:04:05Z")
+	value := fls.String("value", "",
+		"optional value to encode instead of data, e.g.: 21.5")
+	dptName := fls.String("dpt", "",
+		"optional datapoint type to encode value with, e.g.: 9.001")
 
 	cmd := &cobra.Command{
 		Use:   "publish <1/2/3> [data]",
@@ -209,7 +408,7 @@ func publishCommand(
 			ev := v1.Event_EVENT_UNSPECIFIED
 			switch *eventType {
 			case "":
-				if len(args) > 1 {
+				if len(args) > 1 || len(*value) > 0 {
 					// send write event by default if data was given
 					ev = v1.Event_EVENT_WRITE
 				} else {
@@ -263,20 +462,37 @@ func publishCommand(
 			}
 
 			// publish the messsage event
-			_, err = client.Publish(cmd.Context(),
+			res, err := client.Publish(cmd.Context(),
 				connect.NewRequest(&v1.PublishRequest{
 					GroupAddress:    args[0],
 					PhysicalAddress: *physicalAddress,
 					Data:            dataBytes,
 					Event:           ev,
+					IdempotencyKey:  *idempotencyKey,
+					Delay:           *delay,
+					At:              *at,
+					Value:           *value,
+					Dpt:             *dptName,
 				}))
 			if err != nil {
 				return err
 			}
 
+			if len(res.Msg.ScheduledId) > 0 {
+				logger.Info().
+					Str("group-address", args[0]).
+					Str("data", hex.EncodeToString(dataBytes)).
+					Str("value", *value).
+					Str("event-type", ev.String()).
+					Str("scheduled-id", res.Msg.ScheduledId).
+					Msg("message scheduled")
+				return nil
+			}
+
 			logger.Info().
 				Str("group-address", args[0]).
 				Str("data", hex.EncodeToString(dataBytes)).
+				Str("value", *value).
 				Str("event-type", ev.String()).
 				Msg("message sent")
 
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/choopm/stdfx/loggingfx/zerologfx"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// scanCommand returns a *cobra.Command to scan a group address range from a ConfigProvider
+func scanCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	fls := pflag.NewFlagSet("scan", pflag.ContinueOnError)
+	interval := fls.String("interval", "",
+		"optional pause between read requests, e.g.: 100ms")
+	timeout := fls.String("timeout", "",
+		"optional wait for late responses after the last read, e.g.: 3s")
+	dptName := fls.String("dpt", "",
+		"optional datapoint type to check responses against, e.g.: 1.001")
+
+	cmd := &cobra.Command{
+		Use:   "scan <1/2/0[..1/2/255]>",
+		Short: "scan - connects to knxrpc and reads a range of group addresses",
+		Long:  "reports which group addresses answered",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// parse range
+			from, to, _ := strings.Cut(args[0], "..")
+
+			// fetch the config
+			cfg, err := configProvider.Config()
+			if err != nil {
+				return err
+			}
+
+			// rebuild logger and make it global
+			logger, err := zerologfx.New(cfg.Log)
+			if err != nil {
+				return err
+			}
+			log.Logger = *logger
+
+			logger.Info().
+				Str("from", from).
+				Str("to", to).
+				Str("host", cfg.Client.Host).
+				Int("port", cfg.Client.Port).
+				Bool("auth", cfg.Client.Auth.Enabled).
+				Msg("scanning group addresses")
+
+			// create the client instance
+			client, err := knxrpc.NewClient(cfg.Client)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.Scan(cmd.Context(),
+				connect.NewRequest(&v1.ScanRequest{
+					From:     from,
+					To:       to,
+					Interval: *interval,
+					Timeout:  *timeout,
+					Dpt:      *dptName,
+				}))
+			if err != nil {
+				return err
+			}
+
+			for _, result := range res.Msg.Results {
+				ev := logger.Info().
+					Str("group-address", result.GroupAddress).
+					Str("physical-address", result.PhysicalAddress).
+					Str("data", hex.EncodeToString(result.Data))
+				if len(*dptName) > 0 {
+					ev = ev.
+						Bool("dpt-valid", result.DptValid).
+						Str("value", result.Value)
+				}
+				ev.Msg("group address answered")
+			}
+
+			logger.Info().
+				Uint32("scanned", res.Msg.Scanned).
+				Int("answered", len(res.Msg.Results)).
+				Msg("scan finished")
+
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(fls)
+
+	return cmd
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/hex"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/choopm/stdfx/loggingfx/zerologfx"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// scheduledCommand returns a *cobra.Command to manage scheduled publishes from a ConfigProvider
+func scheduledCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scheduled",
+		Short: "scheduled - connects to knxrpc and manages scheduled publishes",
+		Long:  "publishes are scheduled using publish --delay or --at",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list - lists scheduled publishes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.ListScheduled(cmd.Context(),
+				connect.NewRequest(&v1.ListScheduledRequest{}))
+			if err != nil {
+				return err
+			}
+
+			for _, sp := range res.Msg.Scheduled {
+				logger.Info().
+					Str("id", sp.Id).
+					Str("at", sp.At).
+					Str("group-address", sp.PublishRequest.GroupAddress).
+					Str("data", hex.EncodeToString(sp.PublishRequest.Data)).
+					Str("event-type", sp.PublishRequest.Event.String()).
+					Msg("scheduled publish")
+			}
+
+			return nil
+		},
+	}
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "cancel - cancels a scheduled publish",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.CancelScheduled(cmd.Context(),
+				connect.NewRequest(&v1.CancelScheduledRequest{
+					Id: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("id", args[0]).
+				Msg("scheduled publish cancelled")
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(listCmd, cancelCmd)
+
+	return cmd
+}
+
+// newClient returns a GroupAddressServiceClient and logger from a ConfigProvider
+func newClient(
+	configProvider configfx.Provider[knxrpc.Config],
+) (v1Connect.GroupAddressServiceClient, *zerolog.Logger, error) {
+	// fetch the config
+	cfg, err := configProvider.Config()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// rebuild logger and make it global
+	logger, err := zerologfx.New(cfg.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Logger = *logger
+
+	// create the client instance
+	client, err := knxrpc.NewClient(cfg.Client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, logger, nil
+}
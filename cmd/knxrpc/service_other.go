@@ -0,0 +1,42 @@
+//go:build !windows
+
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+
+	"github.com/choopm/knxrpc"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+)
+
+// serviceCommand returns a hidden *cobra.Command as services are managed
+// by systemd on other platforms
+func serviceCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	return &cobra.Command{
+		Use:    "service",
+		Short:  "service - manages the Windows service",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("services are supported on Windows only, use systemd instead")
+		},
+	}
+}
@@ -0,0 +1,222 @@
+//go:build windows
+
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/choopm/knxrpc"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// serviceCommand returns a *cobra.Command to manage the Windows service
+func serviceCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	fls := pflag.NewFlagSet("service", pflag.ContinueOnError)
+	name := fls.String("name", "knxrpc",
+		"name of the Windows service")
+
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "service - installs, starts, stops or removes the Windows service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.PersistentFlags().AddFlagSet(fls)
+
+	// install subcommand
+	installFls := pflag.NewFlagSet("install", pflag.ContinueOnError)
+	account := installFls.String("account", `NT AUTHORITY\LocalService`,
+		"account to run the service as, needs read access to the config")
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "installs the service using the current config file",
+		Long:  "the service starts automatically and is restarted on failures",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// locate the config file to pass it to the service
+			if _, err := configProvider.Config(); err != nil {
+				return err
+			}
+			file, err := filepath.Abs(configProvider.Viper().ConfigFileUsed())
+			if err != nil {
+				return err
+			}
+			exe, err := os.Executable()
+			if err != nil {
+				return err
+			}
+
+			m, err := mgr.Connect()
+			if err != nil {
+				return err
+			}
+			defer m.Disconnect() // nolint:errcheck
+
+			s, err := m.CreateService(*name, exe, mgr.Config{
+				DisplayName:      "knxrpc",
+				Description:      "ConnectRPC server for KNX group addresses",
+				StartType:        mgr.StartAutomatic,
+				ServiceStartName: *account,
+			}, "-f", file, "service", "run", "--name", *name)
+			if err != nil {
+				return err
+			}
+			defer s.Close() // nolint:errcheck
+
+			err = s.SetRecoveryActions([]mgr.RecoveryAction{
+				{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+			}, uint32((24 * time.Hour).Seconds()))
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "installed service %s using %s\n", *name, file)
+
+			return nil
+		},
+	}
+	installCmd.Flags().AddFlagSet(installFls)
+	cmd.AddCommand(installCmd)
+
+	// uninstall subcommand
+	cmd.AddCommand(&cobra.Command{
+		Use:   "uninstall",
+		Short: "removes the service",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withService(*name, func(s *mgr.Service) error {
+				return s.Delete()
+			})
+		},
+	})
+
+	// start subcommand
+	cmd.AddCommand(&cobra.Command{
+		Use:   "start",
+		Short: "starts the service",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withService(*name, func(s *mgr.Service) error {
+				return s.Start()
+			})
+		},
+	})
+
+	// stop subcommand
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stop",
+		Short: "stops the service",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withService(*name, func(s *mgr.Service) error {
+				_, err := s.Control(svc.Stop)
+				return err
+			})
+		},
+	})
+
+	// run subcommand, invoked by the service control manager
+	cmd.AddCommand(&cobra.Command{
+		Use:    "run",
+		Short:  "runs the server as service",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return svc.Run(*name, &service{
+				ctx:            cmd.Context(),
+				configProvider: configProvider,
+			})
+		},
+	})
+
+	return cmd
+}
+
+// withService opens the service name and calls fn with it
+func withService(name string, fn func(s *mgr.Service) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect() // nolint:errcheck
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("service %s: %s", name, err)
+	}
+	defer s.Close() // nolint:errcheck
+
+	return fn(s)
+}
+
+// service runs the server as svc.Handler
+type service struct {
+	ctx            context.Context
+	configProvider configfx.Provider[knxrpc.Config]
+}
+
+// Execute runs the server until stopped by the service control manager
+func (s *service) Execute(args []string, r <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- runServer(ctx, s.configProvider)
+	}()
+
+	status <- svc.Status{
+		State:   svc.Running,
+		Accepts: svc.AcceptStop | svc.AcceptShutdown,
+	}
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				// lets the recovery actions restart the service
+				return true, 1
+			}
+			return false, 0
+
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				status <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				cancel()
+				<-done
+				return false, 0
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+)
+
+// statusCommand returns a *cobra.Command to print the tunnel state from a ConfigProvider
+func statusCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "status - connects to knxrpc and prints the state of the KNX tunnel",
+		Long:  "requires an admin key",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.GetStatus(cmd.Context(),
+				connect.NewRequest(&v1.GetStatusRequest{}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("gateway", res.Msg.Gateway).
+				Str("individual-address", res.Msg.IndividualAddress).
+				Str("connected-at", res.Msg.ConnectedAt).
+				Msg("status")
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+)
+
+// subscribersCommand returns a *cobra.Command to list open streams from a ConfigProvider
+func subscribersCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "subscribers",
+		Short: "subscribers - connects to knxrpc and lists open subscribe streams",
+		Long:  "requires an admin key",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.ListSubscribers(cmd.Context(),
+				connect.NewRequest(&v1.ListSubscribersRequest{}))
+			if err != nil {
+				return err
+			}
+
+			for _, sub := range res.Msg.Subscribers {
+				logger.Info().
+					Str("id", sub.Id).
+					Str("identity", sub.Identity).
+					Str("peer", sub.Peer).
+					Str("started-at", sub.StartedAt).
+					Str("group-addresses", strings.Join(sub.GroupAddresses, ",")).
+					Str("event-filter", sub.Event.String()).
+					Uint64("delivered", sub.Delivered).
+					Uint64("filtered", sub.Filtered).
+					Uint64("dropped", sub.Dropped).
+					Msg("subscriber")
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/choopm/knxrpc"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/choopm/stdfx/configfx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// transactionCommand returns a *cobra.Command to manage transactions from a ConfigProvider
+func transactionCommand(
+	configProvider configfx.Provider[knxrpc.Config],
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transaction",
+		Short: "transaction - connects to knxrpc and writes values which can be reverted",
+		Long:  "previous values are read before writing and restored on revert or expiry",
+	}
+
+	fls := pflag.NewFlagSet("apply", pflag.ContinueOnError)
+	expires := fls.String("expires", "",
+		"optional duration after which the transaction is reverted, e.g.: 1h")
+
+	applyCmd := &cobra.Command{
+		Use:   "apply <1/2/3=data>...",
+		Short: "apply - writes hex data to group addresses",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &v1.TransactionRequest{
+				Expires: *expires,
+			}
+			for _, arg := range args {
+				ga, data, ok := strings.Cut(arg, "=")
+				if !ok {
+					return fmt.Errorf("invalid write %q, expected 1/2/3=data", arg)
+				}
+				dataBytes, err := hex.DecodeString(data)
+				if err != nil {
+					return fmt.Errorf("unable to decode hex data: %v", err)
+				}
+				req.Writes = append(req.Writes, &v1.GroupAddressValue{
+					GroupAddress: ga,
+					Data:         dataBytes,
+				})
+			}
+
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			res, err := client.Transaction(cmd.Context(), connect.NewRequest(req))
+			if err != nil {
+				return err
+			}
+
+			for _, prev := range res.Msg.Previous {
+				logger.Info().
+					Str("group-address", prev.GroupAddress).
+					Str("data", hex.EncodeToString(prev.Data)).
+					Msg("previous value")
+			}
+			logger.Info().
+				Str("id", res.Msg.Id).
+				Msg("transaction applied")
+
+			return nil
+		},
+	}
+	applyCmd.Flags().AddFlagSet(fls)
+
+	commitCmd := &cobra.Command{
+		Use:   "commit <id>",
+		Short: "commit - keeps the written values",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.CommitTransaction(cmd.Context(),
+				connect.NewRequest(&v1.CommitTransactionRequest{
+					Id: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("id", args[0]).
+				Msg("transaction committed")
+
+			return nil
+		},
+	}
+
+	revertCmd := &cobra.Command{
+		Use:   "revert <id>",
+		Short: "revert - restores the previous values",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, logger, err := newClient(configProvider)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.RevertTransaction(cmd.Context(),
+				connect.NewRequest(&v1.RevertTransactionRequest{
+					Id: args[0],
+				}))
+			if err != nil {
+				return err
+			}
+
+			logger.Info().
+				Str("id", args[0]).
+				Msg("transaction reverted")
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(applyCmd, commitCmd, revertCmd)
+
+	return cmd
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// compressionNone disables compression of a client
+	compressionNone = "none"
+	// compressionGzip is the name of the gzip algorithm
+	compressionGzip = "gzip"
+	// compressionZstd is the name of the zstd algorithm
+	compressionZstd = "zstd"
+)
+
+// CompressionConfig holds the compression config of the rpc handlers
+type CompressionConfig struct {
+	// Gzip whether to support gzip compressed requests and responses
+	Gzip bool `mapstructure:"gzip" default:"true"`
+
+	// Zstd whether to support zstd compressed requests and responses
+	Zstd bool `mapstructure:"zstd" default:"false"`
+
+	// MinBytes is the minimum size of a message to compress
+	MinBytes int `mapstructure:"minBytes" default:"1024"`
+}
+
+// Validate validates the CompressionConfig
+func (c *CompressionConfig) Validate() error {
+	if c.MinBytes < 0 {
+		return fmt.Errorf("invalid rpc.compression.minBytes: %d", c.MinBytes)
+	}
+
+	return nil
+}
+
+// handlerOptions returns the connect.HandlerOptions of the config
+func (c *CompressionConfig) handlerOptions() []connect.HandlerOption {
+	opts := []connect.HandlerOption{
+		connect.WithCompressMinBytes(c.MinBytes),
+	}
+	if !c.Gzip {
+		// gzip is registered by default
+		opts = append(opts, connect.WithCompression(compressionGzip, nil, nil))
+	}
+	if c.Zstd {
+		opts = append(opts, connect.WithCompression(compressionZstd,
+			newZstdDecompressor, newZstdCompressor))
+	}
+
+	return opts
+}
+
+// compressionClientOptions returns the connect.ClientOptions to request
+// responses compressed by algorithm, any of none, gzip or zstd
+func compressionClientOptions(algorithm string) []connect.ClientOption {
+	switch algorithm {
+	case compressionNone:
+		return []connect.ClientOption{
+			connect.WithAcceptCompression(compressionGzip, nil, nil),
+		}
+	case compressionZstd:
+		// the last registered algorithm is preferred, gzip stays a fallback
+		return []connect.ClientOption{
+			connect.WithAcceptCompression(compressionZstd,
+				newZstdDecompressor, newZstdCompressor),
+		}
+	}
+
+	return nil
+}
+
+// zstdDecompressor implements connect.Decompressor using a zstd.Decoder
+type zstdDecompressor struct {
+	*zstd.Decoder
+}
+
+// newZstdDecompressor returns a fresh connect.Decompressor for zstd
+func newZstdDecompressor() connect.Decompressor {
+	// errors are only returned for invalid options
+	d, _ := zstd.NewReader(nil)
+	return &zstdDecompressor{Decoder: d}
+}
+
+// Reset implements connect.Decompressor
+func (d *zstdDecompressor) Reset(r io.Reader) error {
+	return d.Decoder.Reset(r)
+}
+
+// Close implements connect.Decompressor, the decoder is kept for reuse
+func (d *zstdDecompressor) Close() error {
+	return nil
+}
+
+// newZstdCompressor returns a fresh connect.Compressor for zstd
+func newZstdCompressor() connect.Compressor {
+	// errors are only returned for invalid options
+	e, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	return e
+}
@@ -18,6 +18,7 @@ package knxrpc
 
 import (
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/choopm/stdfx/loggingfx"
@@ -34,7 +35,7 @@ type Config struct {
 	// RPC is the rpc config, required
 	RPC RPCConfig `mapstructure:"rpc"`
 
-	// Client is the client config to test the server, optional
+	// Client is the config of the client subcommands, optional and unused by the server
 	Client ClientConfig `mapstructure:"knxrpc"`
 }
 
@@ -46,6 +47,14 @@ func (c *Config) Validate() error {
 	if err := c.RPC.Validate(); err != nil {
 		return err
 	}
+	if len(c.secretReferences()) > 0 && len(c.RPC.Secrets.Provider) == 0 {
+		return fmt.Errorf("secret references require rpc.secrets.provider")
+	}
+	for _, ref := range c.secretReferences() {
+		if provider, _, _, _ := parseSecretRef(ref); provider != c.RPC.Secrets.Provider {
+			return fmt.Errorf("secret reference %s does not match rpc.secrets.provider", ref)
+		}
+	}
 
 	return nil
 }
@@ -69,6 +78,24 @@ type KNXConfig struct {
 
 	// UseTCP establishes the tunnel using tcp instead of udp
 	UseTCP bool `mapstructure:"useTCP" default:"false"`
+
+	// LocalAddress is the local IPv4 or IPv6 address to bind the tunnel to, optional
+	LocalAddress string `mapstructure:"localAddress"`
+
+	// Interface is the network interface to bind the tunnel to, optional
+	Interface string `mapstructure:"interface"`
+
+	// Deduplication detects and suppresses repeated telegrams, optional
+	Deduplication DeduplicationConfig `mapstructure:"deduplication"`
+
+	// GroupAddresses stores names and datapoint types of group addresses, optional
+	GroupAddresses []GroupAddressConfig `mapstructure:"groupAddresses"`
+
+	// VirtualGroupAddresses computes values of group addresses from other group addresses, optional
+	VirtualGroupAddresses []VirtualGroupAddressConfig `mapstructure:"virtualGroupAddresses"`
+
+	// Bridge forwards telegrams to a second gateway, optional
+	Bridge BridgeConfig `mapstructure:"bridge"`
 }
 
 // Validate validates the KNXConfig
@@ -79,6 +106,26 @@ func (c *KNXConfig) Validate() error {
 	if c.GatwewayPort == 0 {
 		return fmt.Errorf("missing knx.gatewayPort")
 	}
+	if len(c.LocalAddress) > 0 && len(c.Interface) > 0 {
+		return fmt.Errorf("knx.localAddress and knx.interface are exclusive")
+	}
+	if len(c.LocalAddress) > 0 && net.ParseIP(c.LocalAddress) == nil {
+		return fmt.Errorf("invalid knx.localAddress")
+	}
+	if err := c.Deduplication.Validate(); err != nil {
+		return err
+	}
+	for i := range c.GroupAddresses {
+		if err := c.GroupAddresses[i].Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.validateVirtualGroupAddresses(); err != nil {
+		return err
+	}
+	if err := c.Bridge.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -90,6 +137,48 @@ type RPCConfig struct {
 
 	// Webserver config to use
 	Webserver WebserverConfig `mapstructure:"webserver"`
+
+	// PublishFilter restricts events published through RPCs, optional
+	PublishFilter PublishFilterConfig `mapstructure:"publishFilter"`
+
+	// SuppressFilter hides events received from the bus from subscribers, optional
+	SuppressFilter SuppressFilterConfig `mapstructure:"suppressFilter"`
+
+	// Tenants share this instance using their own keys and limits, optional
+	Tenants []TenantConfig `mapstructure:"tenants"`
+
+	// Quota limits concurrent streams, optional
+	Quota QuotaConfig `mapstructure:"quota"`
+
+	// Idempotency deduplicates retried publishes, optional
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+
+	// History records recent events in memory, optional
+	History HistoryConfig `mapstructure:"history"`
+
+	// Secrets fetches referenced secrets from a secret manager, optional
+	Secrets SecretsConfig `mapstructure:"secrets"`
+
+	// Audit logs calls modifying the bus or devices by identity, optional
+	Audit AuditConfig `mapstructure:"audit"`
+
+	// RequestLog logs every RPC, optional
+	RequestLog RequestLogConfig `mapstructure:"requestLog"`
+
+	// Reload configures reloading the config at runtime, optional
+	Reload ReloadConfig `mapstructure:"reload"`
+
+	// Compression configures compressed requests and responses
+	Compression CompressionConfig `mapstructure:"compression"`
+
+	// Resume configures resumable subscriptions, optional
+	Resume ResumeConfig `mapstructure:"resume"`
+
+	// Ack configures acknowledged subscriptions, optional
+	Ack AckConfig `mapstructure:"ack"`
+
+	// Replay configures replaying recent events on subscribing, optional
+	Replay ReplayConfig `mapstructure:"replay"`
 }
 
 // Validate validates the RPCConfig
@@ -97,9 +186,77 @@ func (c *RPCConfig) Validate() error {
 	if err := c.Auth.Validate(); err != nil {
 		return err
 	}
-	if err := c.Auth.Validate(); err != nil {
+	if err := c.Webserver.Validate(); err != nil {
+		return err
+	}
+	if err := c.PublishFilter.Validate(); err != nil {
 		return err
 	}
+	if err := c.SuppressFilter.Validate(); err != nil {
+		return err
+	}
+	if err := c.Quota.Validate(); err != nil {
+		return err
+	}
+	if err := c.Idempotency.Validate(); err != nil {
+		return err
+	}
+	if err := c.History.Validate(); err != nil {
+		return err
+	}
+	if err := c.Secrets.Validate(); err != nil {
+		return err
+	}
+	if err := c.Audit.Validate(); err != nil {
+		return err
+	}
+	if err := c.RequestLog.Validate(); err != nil {
+		return err
+	}
+	if err := c.Reload.Validate(); err != nil {
+		return err
+	}
+	if err := c.Compression.Validate(); err != nil {
+		return err
+	}
+	if err := c.Resume.Validate(); err != nil {
+		return err
+	}
+	if err := c.Ack.Validate(); err != nil {
+		return err
+	}
+	if err := c.Replay.Validate(); err != nil {
+		return err
+	}
+	if c.Webserver.Enabled && c.Webserver.History.Enabled && !c.History.Enabled {
+		return fmt.Errorf("webserver.history requires rpc.history.enabled")
+	}
+	if len(c.Tenants) > 0 && !c.Auth.Enabled {
+		return fmt.Errorf("rpc.tenants require rpc.auth.enabled")
+	}
+	names := map[string]bool{}
+	keys := map[string]bool{c.Auth.SecretKey: true}
+	for _, key := range c.Auth.Keys {
+		if keys[key.SecretKey] {
+			return fmt.Errorf("duplicate secretKey in rpc.auth.keys(%s)", key.Name)
+		}
+		keys[key.SecretKey] = true
+	}
+	for i := range c.Tenants {
+		if err := c.Tenants[i].Validate(); err != nil {
+			return err
+		}
+		if names[c.Tenants[i].Name] {
+			return fmt.Errorf("duplicate rpc.tenants.name %s", c.Tenants[i].Name)
+		}
+		names[c.Tenants[i].Name] = true
+		for _, key := range c.Tenants[i].SecretKeys {
+			if keys[key] {
+				return fmt.Errorf("duplicate secretKey in rpc.tenants(%s)", c.Tenants[i].Name)
+			}
+			keys[key] = true
+		}
+	}
 
 	return nil
 }
@@ -118,11 +275,35 @@ type WebserverConfig struct {
 	// LogRequests whether to log requests
 	LogRequests bool `mapstructure:"logRequests"`
 
+	// ReusePort whether to bind using SO_REUSEPORT so that a new process
+	// can take over the port before the old one exits
+	ReusePort bool `mapstructure:"reusePort" default:"false"`
+
+	// ShutdownTimeout to wait for in-flight requests when stopping
+	ShutdownTimeout time.Duration `mapstructure:"shutdownTimeout" default:"10s"`
+
+	// TrustedProxies are IPs or CIDRs of reverse proxies whose
+	// X-Forwarded-For header and PROXY protocol header identify the peer
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+
+	// ProxyProtocol whether trusted proxies prefix connections
+	// with a PROXY protocol v1 or v2 header
+	ProxyProtocol bool `mapstructure:"proxyProtocol" default:"false"`
+
 	// Swagger config to use
 	Swagger SwaggerConfig `mapstructure:"swagger"`
 
 	// Metrics config to use
 	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// History config to use
+	History HistoryDownloadConfig `mapstructure:"history"`
+
+	// UI config to use
+	UI UIConfig `mapstructure:"ui"`
+
+	// OpenAPI config to use
+	OpenAPI OpenAPIConfig `mapstructure:"openapi"`
 }
 
 // Validate validates the HTTPConfig
@@ -137,12 +318,30 @@ func (c *WebserverConfig) Validate() error {
 	if c.Port == 0 {
 		return fmt.Errorf("missing webserver.port")
 	}
+	if c.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid webserver.shutdownTimeout: %s", c.ShutdownTimeout)
+	}
+	if _, err := parseTrustedProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid webserver.%s", err)
+	}
+	if c.ProxyProtocol && len(c.TrustedProxies) == 0 {
+		return fmt.Errorf("missing webserver.trustedProxies for proxyProtocol")
+	}
 	if err := c.Swagger.Validate(); err != nil {
 		return err
 	}
 	if err := c.Metrics.Validate(); err != nil {
 		return err
 	}
+	if err := c.History.Validate(); err != nil {
+		return err
+	}
+	if err := c.UI.Validate(); err != nil {
+		return err
+	}
+	if err := c.OpenAPI.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -157,6 +356,9 @@ type SwaggerConfig struct {
 
 	// RootRedirect redirects / to <Path>/ if enabled
 	RootRedirect bool `mapstructure:"rootRedirect" default:"false"`
+
+	// BasicAuth protects swagger using HTTP Basic auth, optional
+	BasicAuth BasicAuthConfig `mapstructure:"basicAuth"`
 }
 
 // Validate validates the SwaggerConfig
@@ -168,6 +370,53 @@ func (c *SwaggerConfig) Validate() error {
 	if len(c.Path) == 0 {
 		return fmt.Errorf("missing webserver.swagger.path")
 	}
+	if err := c.BasicAuth.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UIConfig holds the web UI configuration
+type UIConfig struct {
+	// Enabled whether to serve the web UI
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Path to serve the web UI on
+	Path string `mapstructure:"path" default:"/ui"`
+}
+
+// Validate validates the UIConfig
+func (c *UIConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Path) == 0 {
+		return fmt.Errorf("missing webserver.ui.path")
+	}
+
+	return nil
+}
+
+// OpenAPIConfig holds the OpenAPI spec configuration
+type OpenAPIConfig struct {
+	// Enabled whether to serve the OpenAPI spec
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Path to serve the OpenAPI spec on
+	Path string `mapstructure:"path" default:"/openapi.json"`
+}
+
+// Validate validates the OpenAPIConfig
+func (c *OpenAPIConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Path) == 0 {
+		return fmt.Errorf("missing webserver.openapi.path")
+	}
 
 	return nil
 }
@@ -182,6 +431,9 @@ type MetricsConfig struct {
 
 	// Auth config to use
 	Auth AuthConfig `mapstructure:"auth"`
+
+	// BasicAuth accepts HTTP Basic credentials instead of Auth, optional
+	BasicAuth BasicAuthConfig `mapstructure:"basicAuth"`
 }
 
 // Validate validates the MetricsConfig
@@ -196,6 +448,37 @@ func (c *MetricsConfig) Validate() error {
 	if err := c.Auth.Validate(); err != nil {
 		return err
 	}
+	if err := c.BasicAuth.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HistoryDownloadConfig holds the history download configuration
+type HistoryDownloadConfig struct {
+	// Enabled whether to serve history downloads, requires rpc.history
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Path to serve history downloads on
+	Path string `mapstructure:"path" default:"/history"`
+
+	// Auth config to use
+	Auth AuthConfig `mapstructure:"auth"`
+}
+
+// Validate validates the HistoryDownloadConfig
+func (c *HistoryDownloadConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Path) == 0 {
+		return fmt.Errorf("missing webserver.history.path")
+	}
+	if err := c.Auth.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -216,6 +499,14 @@ type ClientConfig struct {
 
 	// InsecureTLS whether to use insecureSkipVerify
 	InsecureTLS bool `mapstructure:"insecureTLS"`
+
+	// HTTP2 whether to use HTTP/2 without TLS as required by SubscribeAcked,
+	// TLS connections negotiate HTTP/2 anyway
+	HTTP2 bool `mapstructure:"http2" default:"false"`
+
+	// Compression is the algorithm to request compressed responses with,
+	// one of none, gzip or zstd
+	Compression string `mapstructure:"compression" default:"gzip"`
 }
 
 // Validate validates the ClientConfig
@@ -226,6 +517,11 @@ func (c *ClientConfig) Validate() error {
 	if c.Port == 0 {
 		return fmt.Errorf("missing knxrpc.port")
 	}
+	switch c.Compression {
+	case "", compressionNone, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("invalid knxrpc.compression: %s", c.Compression)
+	}
 	if err := c.Auth.Validate(); err != nil {
 		return err
 	}
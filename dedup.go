@@ -0,0 +1,126 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// DeduplicationConfig holds the rules to detect repeated telegrams
+type DeduplicationConfig struct {
+	// Enabled suppresses detected repeats, they are only counted otherwise
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Window is the time in which an identical telegram is a repeat,
+	// 0 only detects telegrams having the repeat flag set
+	Window time.Duration `mapstructure:"window" default:"500ms"`
+}
+
+// Validate validates the DeduplicationConfig
+func (c *DeduplicationConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("knx.deduplication.window must not be negative")
+	}
+
+	return nil
+}
+
+// repeatKey identifies identical telegrams
+type repeatKey struct {
+	source      cemi.IndividualAddr
+	destination uint16
+	command     cemi.APCI
+	data        string
+}
+
+// repeatDetector detects repeated telegrams,
+// it is only used by the bus reader and not synchronized.
+type repeatDetector struct {
+	config *DeduplicationConfig
+
+	// seen stores the last time a telegram was received
+	seen map[repeatKey]time.Time
+	// pruned is the last time seen was pruned
+	pruned time.Time
+
+	// repeats counts detected repeats, nil if disabled
+	repeats otelmetric.Int64Counter
+}
+
+// setupRepeatDetector sets up s.repeats
+func (s *Server) setupRepeatDetector() (err error) {
+	s.repeats = &repeatDetector{
+		config: &s.config.KNX.Deduplication,
+		seen:   map[repeatKey]time.Time{},
+	}
+	if s.meterProvider == nil {
+		return nil
+	}
+
+	s.repeats.repeats, err = s.meterProvider.Meter("github.com/choopm/knxrpc").Int64Counter(
+		"knxrpc.bus.repeats",
+		otelmetric.WithDescription("Repeated telegrams received from the bus, by whether they were suppressed"),
+		otelmetric.WithUnit("{telegram}"),
+	)
+
+	return err
+}
+
+// check returns whether ind is a repeated telegram and should be suppressed
+func (d *repeatDetector) check(ind *cemi.LDataInd, app *cemi.AppData) bool {
+	repeat := ind.Control1&cemi.Control1NoRepeat == 0
+
+	if d.config.Window > 0 {
+		now := time.Now()
+		key := repeatKey{
+			source:      ind.Source,
+			destination: ind.Destination,
+			command:     app.Command,
+			data:        string(app.Data),
+		}
+		// the window starts at the original telegram
+		if last, ok := d.seen[key]; ok && now.Sub(last) < d.config.Window {
+			repeat = true
+		} else {
+			d.seen[key] = now
+		}
+
+		// forget telegrams outside of the window
+		if now.Sub(d.pruned) > d.config.Window {
+			for k, t := range d.seen {
+				if now.Sub(t) >= d.config.Window {
+					delete(d.seen, k)
+				}
+			}
+			d.pruned = now
+		}
+	}
+
+	if repeat && d.repeats != nil {
+		d.repeats.Add(context.Background(), 1, otelmetric.WithAttributes(
+			attribute.Bool("suppressed", d.config.Enabled),
+		))
+	}
+
+	return repeat && d.config.Enabled
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// transport layer control commands of cemi.ControlData
+const (
+	tpciConnect    uint8 = 0
+	tpciDisconnect uint8 = 1
+	tpciAck        uint8 = 2
+	tpciNak        uint8 = 3
+)
+
+var (
+	ErrDeviceNoResponse = errors.New("no response from device")
+	ErrDeviceNak        = errors.New("device rejected the request")
+)
+
+// registerDeviceListener returns a channel receiving management frames.
+// The returned func must be called to unregister it.
+func (s *Server) registerDeviceListener() (<-chan *cemi.LData, func()) {
+	ch := make(chan *cemi.LData, 16)
+
+	s.m_deviceListeners.Lock()
+	s.deviceListeners = append(s.deviceListeners, ch)
+	s.m_deviceListeners.Unlock()
+
+	return ch, func() {
+		s.m_deviceListeners.Lock()
+		defer s.m_deviceListeners.Unlock()
+
+		for i, l := range s.deviceListeners {
+			if l != ch {
+				continue
+			}
+
+			// move the last element to our index and drop the last element
+			s.deviceListeners[i] = s.deviceListeners[len(s.deviceListeners)-1]
+			s.deviceListeners = s.deviceListeners[:len(s.deviceListeners)-1]
+
+			return
+		}
+	}
+}
+
+// dispatchToDeviceListeners sends a management frame to device listeners
+func (s *Server) dispatchToDeviceListeners(ldata *cemi.LData) {
+	s.m_deviceListeners.RLock()
+	defer s.m_deviceListeners.RUnlock()
+
+	for _, l := range s.deviceListeners {
+		select {
+		case l <- ldata:
+		default:
+			s.log.Warn().
+				Str("source", ldata.Source.String()).
+				Msg("device listener is full, dropping management frame")
+		}
+	}
+}
+
+// newManagementLData returns a point-to-point L_Data frame to dest
+// using system priority as required for management.
+func newManagementLData(dest cemi.IndividualAddr, unit cemi.TransportUnit) cemi.LData {
+	return cemi.LData{
+		Control1: cemi.Control1StdFrame | cemi.Control1NoRepeat |
+			cemi.Control1NoSysBroadcast | cemi.Control1WantAck |
+			cemi.Control1Prio(cemi.PrioSystem),
+		Control2:    cemi.Control2Hops(6),
+		Destination: uint16(dest),
+		Data:        unit,
+	}
+}
+
+// newBroadcastLData returns a broadcast L_Data frame using system priority
+func newBroadcastLData(app *cemi.AppData) cemi.LData {
+	ldata := newManagementLData(0, app)
+	ldata.Control2 |= cemi.Control2GroupAddr
+
+	return ldata
+}
+
+// sendManagement writes a management frame to the bus
+func (s *Server) sendManagement(ldata cemi.LData) error {
+	s.tunnelAddress.fill(&ldata)
+	return s.tunnel.Send(&cemi.LDataReq{LData: ldata})
+}
+
+// deviceConnection is a connection-oriented transport layer
+// connection to a single device.
+type deviceConnection struct {
+	s      *Server
+	dest   cemi.IndividualAddr
+	frames <-chan *cemi.LData
+	seq    uint8
+
+	// close unregisters the listener and releases the device lock
+	close func()
+}
+
+// connectDevice opens a transport layer connection to dest.
+// Only one connection is held at any time, close it using [deviceConnection.Close].
+func (s *Server) connectDevice(ctx context.Context, dest cemi.IndividualAddr) (*deviceConnection, error) {
+	// serialize management, devices only accept a single connection
+	select {
+	case s.deviceLock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	frames, unregister := s.registerDeviceListener()
+	conn := &deviceConnection{
+		s:      s,
+		dest:   dest,
+		frames: frames,
+	}
+	conn.close = func() {
+		unregister()
+		<-s.deviceLock
+	}
+
+	err := s.sendManagement(newManagementLData(dest, &cemi.ControlData{
+		Command: tpciConnect,
+	}))
+	if err != nil {
+		conn.close()
+		return nil, fmt.Errorf("connect %s: %s", dest, err)
+	}
+
+	return conn, nil
+}
+
+// Close disconnects the transport layer connection
+func (c *deviceConnection) Close() error {
+	defer c.close()
+
+	return c.s.sendManagement(newManagementLData(c.dest, &cemi.ControlData{
+		Command: tpciDisconnect,
+	}))
+}
+
+// Send sends a numbered application frame and waits for its acknowledgement
+func (c *deviceConnection) Send(ctx context.Context, command cemi.APCI, data []byte) error {
+	err := c.s.sendManagement(newManagementLData(c.dest, &cemi.AppData{
+		Numbered:  true,
+		SeqNumber: c.seq,
+		Command:   command,
+		Data:      data,
+	}))
+	if err != nil {
+		return err
+	}
+
+	// wait for T_ACK
+	for {
+		ldata, err := c.receive(ctx)
+		if err != nil {
+			return err
+		}
+
+		ctrl, ok := ldata.Data.(*cemi.ControlData)
+		if !ok || !ctrl.Numbered || ctrl.SeqNumber != c.seq {
+			continue
+		}
+
+		switch ctrl.Command {
+		case tpciAck:
+			c.seq = (c.seq + 1) & 15
+			return nil
+		case tpciNak:
+			return ErrDeviceNak
+		}
+	}
+}
+
+// Receive waits for a numbered application frame using command and acknowledges it
+func (c *deviceConnection) Receive(ctx context.Context, command cemi.APCI) (*cemi.AppData, error) {
+	for {
+		ldata, err := c.receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		app, ok := ldata.Data.(*cemi.AppData)
+		if !ok || app.Command != command {
+			continue
+		}
+
+		if app.Numbered {
+			err = c.s.sendManagement(newManagementLData(c.dest, &cemi.ControlData{
+				Numbered:  true,
+				SeqNumber: app.SeqNumber,
+				Command:   tpciAck,
+			}))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return app, nil
+	}
+}
+
+// receive returns the next frame originating from the connected device
+func (c *deviceConnection) receive(ctx context.Context) (*cemi.LData, error) {
+	timeout := time.After(c.s.config.KNX.Timeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return nil, ErrDeviceNoResponse
+		case ldata := <-c.frames:
+			if ldata.Source != c.dest || ldata.Control2.IsGroupAddr() {
+				continue
+			}
+
+			if ctrl, ok := ldata.Data.(*cemi.ControlData); ok &&
+				!ctrl.Numbered && ctrl.Command == tpciDisconnect {
+				return nil, fmt.Errorf("%s disconnected", c.dest)
+			}
+
+			return ldata, nil
+		}
+	}
+}
+
+// readDeviceDescriptor reads the device descriptor type 0 of dest
+func (s *Server) readDeviceDescriptor(ctx context.Context, dest cemi.IndividualAddr) (uint16, error) {
+	conn, err := s.connectDevice(ctx, dest)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close() // nolint:errcheck
+
+	// A_DeviceDescriptor_Read uses the MaskVersionRead APCI
+	if err := conn.Send(ctx, cemi.MaskVersionRead, []byte{0}); err != nil {
+		return 0, err
+	}
+
+	app, err := conn.Receive(ctx, cemi.MaskVersionResponse)
+	if err != nil {
+		return 0, err
+	}
+	if len(app.Data) < 3 {
+		return 0, fmt.Errorf("invalid device descriptor length %d", len(app.Data))
+	}
+
+	return uint16(app.Data[1])<<8 | uint16(app.Data[2]), nil
+}
+
+// restartDevice sends a basic restart to dest
+func (s *Server) restartDevice(ctx context.Context, dest cemi.IndividualAddr) error {
+	conn, err := s.connectDevice(ctx, dest)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() // nolint:errcheck
+
+	// devices restart immediately and usually don't acknowledge
+	err = conn.Send(ctx, cemi.Restart, []byte{0})
+	if err != nil && !errors.Is(err, ErrDeviceNoResponse) {
+		return err
+	}
+
+	return nil
+}
+
+// readIndividualAddresses returns the addresses of devices in programming mode
+// which responded within dur.
+func (s *Server) readIndividualAddresses(
+	ctx context.Context,
+	dur time.Duration,
+) ([]cemi.IndividualAddr, error) {
+	frames, unregister := s.registerDeviceListener()
+	defer unregister()
+
+	err := s.sendManagement(newBroadcastLData(&cemi.AppData{
+		Command: cemi.IndividualAddrRequest,
+		Data:    []byte{0},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []cemi.IndividualAddr{}
+	timeout := time.After(dur)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return ret, nil
+		case ldata := <-frames:
+			app, ok := ldata.Data.(*cemi.AppData)
+			if !ok || app.Command != cemi.IndividualAddrResponse {
+				continue
+			}
+
+			ret = append(ret, ldata.Source)
+		}
+	}
+}
+
+// writeIndividualAddress assigns addr to the device in programming mode
+func (s *Server) writeIndividualAddress(addr cemi.IndividualAddr) error {
+	return s.sendManagement(newBroadcastLData(&cemi.AppData{
+		Command: cemi.IndividualAddrWrite,
+		Data:    []byte{0, byte(addr >> 8), byte(addr)},
+	}))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	deviceV1 "github.com/choopm/knxrpc/knx/device/v1"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// ReadDeviceDescriptor implements knx.device.v1.ReadDeviceDescriptor
+func (s *Server) ReadDeviceDescriptor(
+	ctx context.Context,
+	req *connect.Request[deviceV1.ReadDeviceDescriptorRequest],
+) (*connect.Response[deviceV1.ReadDeviceDescriptorResponse], error) {
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	addr, err := cemi.NewIndividualAddrString(req.Msg.IndividualAddress)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument,
+			fieldErrorf("individual_address", "parse individualAddress: %s", err))
+	}
+
+	maskVersion, err := s.readDeviceDescriptor(ctx, addr)
+	if err != nil {
+		return nil, toDeviceError(err)
+	}
+
+	return connect.NewResponse(&deviceV1.ReadDeviceDescriptorResponse{
+		IndividualAddress: addr.String(),
+		MaskVersion:       uint32(maskVersion),
+	}), nil
+}
+
+// RestartDevice implements knx.device.v1.RestartDevice
+func (s *Server) RestartDevice(
+	ctx context.Context,
+	req *connect.Request[deviceV1.RestartDeviceRequest],
+) (*connect.Response[deviceV1.RestartDeviceResponse], error) {
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	addr, err := cemi.NewIndividualAddrString(req.Msg.IndividualAddress)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument,
+			fieldErrorf("individual_address", "parse individualAddress: %s", err))
+	}
+
+	if err := s.restartDevice(ctx, addr); err != nil {
+		return nil, toDeviceError(err)
+	}
+
+	return connect.NewResponse(&deviceV1.RestartDeviceResponse{}), nil
+}
+
+// ReadIndividualAddresses implements knx.device.v1.ReadIndividualAddresses
+func (s *Server) ReadIndividualAddresses(
+	ctx context.Context,
+	req *connect.Request[deviceV1.ReadIndividualAddressesRequest],
+) (*connect.Response[deviceV1.ReadIndividualAddressesResponse], error) {
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	dur := s.config.KNX.Timeout
+	if req.Msg.For != "" {
+		var err error
+		dur, err = time.ParseDuration(req.Msg.For)
+		if err != nil {
+			return nil, newConnectError(connect.CodeInvalidArgument,
+				fieldErrorf("for", "parsing 'for': %v", err))
+		}
+	}
+
+	addrs, err := s.readIndividualAddresses(ctx, dur)
+	if err != nil {
+		return nil, toDeviceError(err)
+	}
+
+	res := &deviceV1.ReadIndividualAddressesResponse{
+		IndividualAddresses: []string{},
+	}
+	for _, addr := range addrs {
+		res.IndividualAddresses = append(res.IndividualAddresses, addr.String())
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// WriteIndividualAddress implements knx.device.v1.WriteIndividualAddress
+func (s *Server) WriteIndividualAddress(
+	ctx context.Context,
+	req *connect.Request[deviceV1.WriteIndividualAddressRequest],
+) (*connect.Response[deviceV1.WriteIndividualAddressResponse], error) {
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	addr, err := cemi.NewIndividualAddrString(req.Msg.IndividualAddress)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument,
+			fieldErrorf("individual_address", "parse individualAddress: %s", err))
+	}
+
+	// make sure exactly one device is in programming mode,
+	// otherwise all of them would receive the same address.
+	addrs, err := s.readIndividualAddresses(ctx, s.config.KNX.Timeout)
+	if err != nil {
+		return nil, toDeviceError(err)
+	}
+	if len(addrs) != 1 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("expected exactly one device in programming mode, found %d", len(addrs)))
+	}
+
+	if err := s.writeIndividualAddress(addr); err != nil {
+		return nil, toDeviceError(err)
+	}
+
+	return connect.NewResponse(&deviceV1.WriteIndividualAddressResponse{}), nil
+}
+
+// toDeviceError maps device management errors to connect errors
+func toDeviceError(err error) error {
+	switch {
+	case errors.Is(err, ErrDeviceNoResponse):
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	case errors.Is(err, ErrDeviceNak):
+		return connect.NewError(connect.CodeAborted, err)
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeCanceled, err)
+	}
+
+	return connect.NewError(connect.CodeInternal, err)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/dpt"
+)
+
+// GroupAddressConfig holds metadata of a group address
+type GroupAddressConfig struct {
+	// Address is the group address, required
+	// valid format: 1/2/3
+	Address string `mapstructure:"address"`
+
+	// Name is a human readable name, optional
+	Name string `mapstructure:"name"`
+
+	// DPT is the datapoint type used to decode and encode values, optional
+	// valid format: 9.001
+	DPT string `mapstructure:"dpt"`
+}
+
+// Validate validates the GroupAddressConfig
+func (c *GroupAddressConfig) Validate() error {
+	if _, err := cemi.NewGroupAddrString(c.Address); err != nil {
+		return fmt.Errorf("knx.groupAddresses(%s): %s", c.Address, err)
+	}
+	if len(c.DPT) > 0 {
+		if _, ok := dpt.Produce(c.DPT); !ok {
+			return fmt.Errorf("knx.groupAddresses(%s): unsupported dpt: %s", c.Address, c.DPT)
+		}
+	}
+
+	return nil
+}
+
+// lastEvent stores the last value carrying event of a group address
+type lastEvent struct {
+	time  time.Time
+	event *knx.GroupEvent
+}
+
+// newDirectory returns the group address directory of configs or error
+func newDirectory(configs []GroupAddressConfig) (map[cemi.GroupAddr]*GroupAddressConfig, error) {
+	ret := map[cemi.GroupAddr]*GroupAddressConfig{}
+
+	for i := range configs {
+		ga, err := cemi.NewGroupAddrString(configs[i].Address)
+		if err != nil {
+			return nil, fmt.Errorf("parse address(%d): %s", i, err)
+		}
+		if _, ok := ret[ga]; ok {
+			return nil, fmt.Errorf("duplicate address: %s", ga)
+		}
+
+		ret[ga] = &configs[i]
+	}
+
+	return ret, nil
+}
+
+// recordLastEvent remembers event if it carries a value
+func (s *Server) recordLastEvent(event *knx.GroupEvent) {
+	if event.Command == knx.GroupRead {
+		return
+	}
+
+	s.m_lastEvents.Lock()
+	defer s.m_lastEvents.Unlock()
+
+	s.lastEvents[event.Destination] = lastEvent{
+		time:  time.Now(),
+		event: event,
+	}
+}
+
+// decodeEventValue returns the data of event decoded using the directory dpt if known
+func (s *Server) decodeEventValue(event *knx.GroupEvent) string {
+	entry, ok := s.reloadable().directory[event.Destination]
+	if !ok || len(entry.DPT) == 0 || event.Command == knx.GroupRead {
+		return ""
+	}
+
+	value, _ := decodeValue(entry.DPT, event.Data)
+	return value
+}
+
+// listGroupAddresses returns the directory merged with all group addresses
+// seen on the bus visible to tenant t ordered by group address.
+func (s *Server) listGroupAddresses(t *tenant) []*v1.GroupAddressInfo {
+	infos := map[cemi.GroupAddr]*v1.GroupAddressInfo{}
+
+	for ga, entry := range s.reloadable().directory {
+		infos[ga] = &v1.GroupAddressInfo{
+			GroupAddress: ga.String(),
+			Name:         entry.Name,
+			Dpt:          entry.DPT,
+		}
+	}
+
+	s.m_lastEvents.Lock()
+	for ga, last := range s.lastEvents {
+		info, ok := infos[ga]
+		if !ok {
+			info = &v1.GroupAddressInfo{
+				GroupAddress: ga.String(),
+			}
+			infos[ga] = info
+		}
+
+		info.Last = toV1SubscribeResponse(last.event)
+		info.Last.Value = s.decodeEventValue(last.event)
+		info.LastTime = last.time.Format(time.RFC3339)
+	}
+	s.m_lastEvents.Unlock()
+
+	addresses := []cemi.GroupAddr{}
+	for ga := range infos {
+		if !t.allows(ga) {
+			continue
+		}
+		addresses = append(addresses, ga)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i] < addresses[j]
+	})
+
+	ret := []*v1.GroupAddressInfo{}
+	for _, ga := range addresses {
+		ret = append(ret, infos[ga])
+	}
+
+	return ret
+}
+
+// listDatapointTypes returns all supported datapoint types ordered by name
+func listDatapointTypes() []string {
+	ret := dpt.ListSupportedTypes()
+	sort.Strings(ret)
+
+	return ret
+}
+
+// encodePublishValue returns req.Value of event encoded using req.Dpt
+// or the directory dpt of the group address.
+func (s *Server) encodePublishValue(event *knx.GroupEvent, req *v1.PublishRequest) ([]byte, error) {
+	if len(req.Data) > 0 {
+		return nil, fieldErrorf("value", "data and value are mutually exclusive")
+	}
+
+	dptName := req.Dpt
+	if entry, ok := s.reloadable().directory[event.Destination]; ok && len(dptName) == 0 {
+		dptName = entry.DPT
+	}
+	if len(dptName) == 0 {
+		return nil, fieldErrorf("dpt", "missing dpt for %s", event.Destination)
+	}
+
+	data, err := encodeValue(dptName, req.Value)
+	if err != nil {
+		return nil, fieldErrorf("value", "%s", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/knxnet"
+)
+
+// DiagnosisStatus is the outcome of a single Diagnosis
+type DiagnosisStatus int
+
+const (
+	// DiagnosisOK the check passed
+	DiagnosisOK DiagnosisStatus = iota
+	// DiagnosisWarning the check passed with a remark
+	DiagnosisWarning
+	// DiagnosisFailed the check failed
+	DiagnosisFailed
+	// DiagnosisSkipped the check did not apply
+	DiagnosisSkipped
+)
+
+// String returns the name of the DiagnosisStatus
+func (s DiagnosisStatus) String() string {
+	switch s {
+	case DiagnosisOK:
+		return "ok"
+	case DiagnosisWarning:
+		return "warn"
+	case DiagnosisFailed:
+		return "fail"
+	case DiagnosisSkipped:
+		return "skip"
+	}
+
+	return "unknown"
+}
+
+// Diagnosis is the result of a setup check performed by Diagnose
+type Diagnosis struct {
+	// Name of the check
+	Name string
+
+	// Status of the check
+	Status DiagnosisStatus
+
+	// Detail describes the outcome
+	Detail string
+}
+
+// Diagnose checks the setup described by config: config validity,
+// gateway reachability, a tunnel connect including a heartbeat,
+// webserver port availability and an auth round-trip against the
+// knxrpc server of the client config.
+func Diagnose(ctx context.Context, config *Config) []Diagnosis {
+	ret := []Diagnosis{}
+
+	if err := config.Validate(); err != nil {
+		ret = append(ret, Diagnosis{"config", DiagnosisFailed, err.Error()})
+	} else {
+		ret = append(ret, Diagnosis{"config", DiagnosisOK, "valid"})
+	}
+
+	ret = append(ret, diagnoseGateway(&config.KNX))
+	ret = append(ret, diagnoseTunnel(&config.KNX))
+	ret = append(ret, diagnoseWebserver(&config.RPC.Webserver))
+	ret = append(ret, diagnoseAuth(ctx, config)...)
+
+	return ret
+}
+
+// gatewayAddress returns host:port of the gateway of config
+func gatewayAddress(config *KNXConfig) string {
+	return net.JoinHostPort(config.GatwewayHost, strconv.Itoa(config.GatwewayPort))
+}
+
+// diagnoseGateway checks whether the gateway is reachable
+func diagnoseGateway(config *KNXConfig) Diagnosis {
+	name := "gateway"
+	if len(config.GatwewayHost) == 0 {
+		return Diagnosis{name, DiagnosisSkipped, "missing knx.gatewayHost"}
+	}
+	addr := gatewayAddress(config)
+
+	if config.UseTCP {
+		conn, err := net.DialTimeout("tcp", addr, config.Timeout)
+		if err != nil {
+			return Diagnosis{name, DiagnosisFailed, fmt.Sprintf("tcp %s: %s", addr, err)}
+		}
+		conn.Close() // nolint:errcheck
+
+		return Diagnosis{name, DiagnosisOK, fmt.Sprintf("tcp %s reachable", addr)}
+	}
+
+	address, relay, err := relayGateway(config)
+	if err != nil {
+		return Diagnosis{name, DiagnosisFailed, fmt.Sprintf("udp %s: %s", addr, err)}
+	}
+	if relay != nil {
+		defer relay.Close() // nolint:errcheck
+	}
+
+	res, err := knx.DescribeTunnel(address, config.Timeout)
+	if err != nil {
+		return Diagnosis{name, DiagnosisFailed, fmt.Sprintf("udp %s: %s", addr, err)}
+	}
+	if res == nil {
+		// some gateways only answer tunnelling requests
+		return Diagnosis{name, DiagnosisWarning,
+			fmt.Sprintf("udp %s: no description response within %s", addr, config.Timeout)}
+	}
+
+	return Diagnosis{name, DiagnosisOK, fmt.Sprintf("udp %s reachable: %q (%s)",
+		addr, res.DeviceHardware.FriendlyName, res.DeviceHardware.Source)}
+}
+
+// diagnoseTunnel connects a tunnel, performs a single heartbeat and disconnects
+func diagnoseTunnel(config *KNXConfig) Diagnosis {
+	name := "tunnel"
+	if len(config.GatwewayHost) == 0 {
+		return Diagnosis{name, DiagnosisSkipped, "missing knx.gatewayHost"}
+	}
+
+	channel, err := ProbeTunnel(config)
+	if err != nil {
+		return Diagnosis{name, DiagnosisFailed, err.Error()}
+	}
+
+	return Diagnosis{name, DiagnosisOK, fmt.Sprintf("connected on channel %d, heartbeat ok", channel)}
+}
+
+// ProbeTunnel performs the connect, connection state and disconnect
+// requests of a tunnel by hand, as knx.Tunnel reconnects silently on
+// heartbeat failures. It returns the channel assigned by the gateway.
+func ProbeTunnel(config *KNXConfig) (uint8, error) {
+	address, relay, err := relayGateway(config)
+	if err != nil {
+		return 0, fmt.Errorf("dial: %s", err)
+	}
+	if relay != nil {
+		defer relay.Close() // nolint:errcheck
+	}
+
+	var sock knxnet.Socket
+	if config.UseTCP {
+		sock, err = knxnet.DialTunnelTCP(address)
+	} else {
+		sock, err = knxnet.DialTunnelUDP(address)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("dial: %s", err)
+	}
+	defer sock.Close() // nolint:errcheck
+
+	// same host info as knx.Tunnel
+	control := knxnet.HostInfo{Protocol: knxnet.UDP4}
+	if config.UseTCP {
+		control = knxnet.HostInfo{Protocol: knxnet.TCP4}
+	} else if config.SendLocalAddress {
+		control, err = knxnet.HostInfoFromAddress(sock.LocalAddr())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = sock.Send(&knxnet.ConnReq{
+		Layer:   knxnet.TunnelLayerData,
+		Control: control,
+		Tunnel:  control,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("connect: %s", err)
+	}
+	conn, err := awaitService[*knxnet.ConnRes](sock, config.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("connect: %s", err)
+	}
+	if conn.Status != knxnet.NoError {
+		return 0, fmt.Errorf("connect: %s", conn.Status)
+	}
+	defer sock.Send(&knxnet.DiscReq{Channel: conn.Channel, Control: control}) // nolint:errcheck
+
+	err = sock.Send(&knxnet.ConnStateReq{Channel: conn.Channel, Control: control})
+	if err != nil {
+		return 0, fmt.Errorf("heartbeat: %s", err)
+	}
+	state, err := awaitService[*knxnet.ConnStateRes](sock, config.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("heartbeat: %s", err)
+	}
+	if state.Status != knxnet.NoError {
+		return 0, fmt.Errorf("heartbeat: %s", state.Status)
+	}
+
+	return conn.Channel, nil
+}
+
+// awaitService returns the first service of type T received by sock
+func awaitService[T knxnet.Service](sock knxnet.Socket, timeout time.Duration) (T, error) {
+	var zero T
+	deadline := time.After(timeout)
+	for {
+		select {
+		case <-deadline:
+			return zero, fmt.Errorf("no response within %s", timeout)
+		case msg, ok := <-sock.Inbound():
+			if !ok {
+				return zero, errors.New("socket closed")
+			}
+			if res, ok := msg.(T); ok {
+				return res, nil
+			}
+		}
+	}
+}
+
+// diagnoseWebserver checks whether the webserver port is available
+func diagnoseWebserver(config *WebserverConfig) Diagnosis {
+	name := "webserver"
+	if !config.Enabled {
+		return Diagnosis{name, DiagnosisSkipped, "disabled"}
+	}
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+
+	l, err := net.Listen("tcp", addr)
+	if errors.Is(err, syscall.EADDRINUSE) {
+		return Diagnosis{name, DiagnosisWarning,
+			fmt.Sprintf("%s in use, fine if knxrpc is running already", addr)}
+	}
+	if err != nil {
+		return Diagnosis{name, DiagnosisFailed, err.Error()}
+	}
+	l.Close() // nolint:errcheck
+
+	return Diagnosis{name, DiagnosisOK, fmt.Sprintf("%s available", addr)}
+}
+
+// diagnoseAuth lists group addresses of the server of the client config
+// using its credentials and, if auth is enabled, without them.
+func diagnoseAuth(ctx context.Context, config *Config) []Diagnosis {
+	name := "auth"
+	if err := config.Client.Validate(); err != nil {
+		return []Diagnosis{{name, DiagnosisSkipped, err.Error()}}
+	}
+	addr := net.JoinHostPort(config.Client.Host, strconv.Itoa(config.Client.Port))
+
+	status, detail := listGroupAddresses(ctx, config.Client, config.KNX.Timeout)
+	if status == DiagnosisSkipped {
+		return []Diagnosis{{name, status, fmt.Sprintf("%s: %s", addr, detail)}}
+	}
+	ret := []Diagnosis{{name, status, fmt.Sprintf("%s: %s", addr, detail)}}
+	if !config.RPC.Auth.Enabled {
+		return ret
+	}
+
+	anonymous := config.Client
+	anonymous.Auth = AuthConfig{}
+	status, detail = listGroupAddresses(ctx, anonymous, config.KNX.Timeout)
+	switch status {
+	case DiagnosisOK:
+		status, detail = DiagnosisFailed, "accepted without credentials"
+	case DiagnosisFailed:
+		status, detail = DiagnosisOK, "rejected without credentials"
+	}
+
+	return append(ret, Diagnosis{"anonymous", status, fmt.Sprintf("%s: %s", addr, detail)})
+}
+
+// listGroupAddresses calls ListGroupAddresses using config and returns
+// the status of the call, skipped if the server is unavailable.
+func listGroupAddresses(ctx context.Context, config ClientConfig, timeout time.Duration) (DiagnosisStatus, string) {
+	client, err := NewClient(config)
+	if err != nil {
+		return DiagnosisFailed, err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = client.ListGroupAddresses(ctx, connect.NewRequest(&v1.ListGroupAddressesRequest{}))
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return DiagnosisSkipped, fmt.Sprintf("server not reachable: %s", err)
+	case connect.CodeUnauthenticated:
+		return DiagnosisFailed, "credentials rejected"
+	case connect.CodePermissionDenied:
+		return DiagnosisWarning, "authenticated but not permitted to list group addresses"
+	}
+	if err != nil {
+		return DiagnosisFailed, err.Error()
+	}
+
+	return DiagnosisOK, "authenticated"
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/vapourismo/knx-go/knx/dpt"
+)
+
+// decodeValue returns data decoded using datapoint type dptName
+func decodeValue(dptName string, data []byte) (string, bool) {
+	_, text, ok := unpackValue(dptName, data)
+	return text, ok
+}
+
+// unpackValue returns data decoded using datapoint type dptName as its
+// underlying value and formatted text
+func unpackValue(dptName string, data []byte) (reflect.Value, string, bool) {
+	dp, ok := dpt.Produce(dptName)
+	if !ok {
+		return reflect.Value{}, "", false
+	}
+	if err := dp.Unpack(data); err != nil {
+		return reflect.Value{}, "", false
+	}
+
+	v := reflect.ValueOf(dp)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	return v, dp.String(), true
+}
+
+// encodeValue returns value encoded using datapoint type dptName.
+// Only datapoint types with a boolean or numeric representation are supported.
+func encodeValue(dptName string, value string) ([]byte, error) {
+	dp, ok := dpt.Produce(dptName)
+	if !ok {
+		return nil, fmt.Errorf("unsupported dpt: %s", dptName)
+	}
+
+	v := reflect.ValueOf(dp)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	value = strings.TrimSpace(value)
+	switch v.Kind() {
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "1", "true", "on":
+			v.SetBool(true)
+		case "0", "false", "off":
+			v.SetBool(false)
+		default:
+			return nil, fmt.Errorf("invalid boolean value: %s", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value: %s", err)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned value: %s", err)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return nil, fmt.Errorf("invalid float value: %s", err)
+		}
+		v.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("dpt %s does not support encoding values", dptName)
+	}
+
+	return dp.Pack(), nil
+}
+
+// encodeNumericValue returns value encoded using datapoint type dptName,
+// it is rounded for integer types
+func encodeNumericValue(dptName string, value float64) ([]byte, error) {
+	if dp, ok := dpt.Produce(dptName); ok {
+		if v := reflect.Indirect(reflect.ValueOf(dp)); v.CanInt() || v.CanUint() {
+			value = math.Round(value)
+		}
+	}
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+
+	return encodeValue(dptName, text)
+}
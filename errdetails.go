@@ -0,0 +1,125 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// errorDomain is the domain of google.rpc.ErrorInfo details
+const errorDomain = "knxrpc.choopm.github.com"
+
+// reasons of google.rpc.ErrorInfo details
+const (
+	reasonTenantGroupAddress = "TENANT_GROUP_ADDRESS"
+	reasonPublishDenied      = "PUBLISH_DENIED"
+)
+
+// fieldError is an invalid request field,
+// it is returned as google.rpc.BadRequest detail.
+type fieldError struct {
+	// field is the path of the field, e.g. writes[1].group_address
+	field string
+
+	// msg describes the error
+	msg string
+}
+
+// fieldErrorf returns a *fieldError of field formatted like fmt.Errorf
+func fieldErrorf(field string, format string, args ...any) error {
+	return &fieldError{
+		field: field,
+		msg:   fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements error
+func (e *fieldError) Error() string {
+	return e.msg
+}
+
+// groupAddressError is an error concerning a group address,
+// it is returned as google.rpc.ErrorInfo detail.
+type groupAddressError struct {
+	// reason is the ErrorInfo reason
+	reason string
+
+	// address is the affected group address
+	address cemi.GroupAddr
+
+	// err is the wrapped error
+	err error
+}
+
+// newGroupAddressError returns err wrapped into a *groupAddressError
+func newGroupAddressError(reason string, address cemi.GroupAddr, err error) error {
+	return &groupAddressError{
+		reason:  reason,
+		address: address,
+		err:     err,
+	}
+}
+
+// Error implements error
+func (e *groupAddressError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *groupAddressError) Unwrap() error {
+	return e.err
+}
+
+// newConnectError returns a *connect.Error of code wrapping err,
+// details are added for *fieldError and *groupAddressError.
+func newConnectError(code connect.Code, err error) *connect.Error {
+	cerr := connect.NewError(code, err)
+
+	var ferr *fieldError
+	if errors.As(err, &ferr) {
+		detail, derr := connect.NewErrorDetail(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       ferr.field,
+				Description: ferr.msg,
+			}},
+		})
+		if derr == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+
+	var gerr *groupAddressError
+	if errors.As(err, &gerr) {
+		detail, derr := connect.NewErrorDetail(&errdetails.ErrorInfo{
+			Reason: gerr.reason,
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"group_address": gerr.address.String(),
+			},
+		})
+		if derr == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+
+	return cerr
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// fieldMask projects responses onto the fields requested by a subscriber
+type fieldMask struct {
+	// paths to populate, normalized
+	paths []string
+	// key identifies the projection of a response shared by subscribers
+	key string
+}
+
+// newFieldMask returns the *fieldMask of mask validated against
+// the response message msg, nil for an empty mask, or error
+func newFieldMask(msg proto.Message, mask *fieldmaskpb.FieldMask) (*fieldMask, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+	if !mask.IsValid(msg) {
+		return nil, fieldErrorf("fields", "invalid fields: %s",
+			strings.Join(mask.GetPaths(), ","))
+	}
+
+	normalized := &fieldmaskpb.FieldMask{
+		Paths: append([]string{}, mask.GetPaths()...),
+	}
+	normalized.Normalize()
+
+	return &fieldMask{
+		paths: normalized.Paths,
+		key: string(msg.ProtoReflect().Descriptor().FullName()) + ":" +
+			strings.Join(normalized.Paths, ","),
+	}, nil
+}
+
+// project returns a copy of msg populating only the fields of m. The copy
+// shares values with msg, so msg must not be modified afterwards.
+func (m *fieldMask) project(msg proto.Message) proto.Message {
+	src := msg.ProtoReflect()
+	dst := src.New()
+	projectPaths(src, dst, m.paths)
+
+	return dst.Interface()
+}
+
+// projectPaths copies the fields of paths from src to dst
+func projectPaths(src, dst protoreflect.Message, paths []string) {
+	nested := map[protoreflect.Name][]string{}
+	for _, path := range paths {
+		name, rest, ok := strings.Cut(path, ".")
+		fd := src.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !src.Has(fd) {
+			continue
+		}
+		if !ok {
+			dst.Set(fd, src.Get(fd))
+			continue
+		}
+		nested[fd.Name()] = append(nested[fd.Name()], rest)
+	}
+
+	for name, rest := range nested {
+		fd := src.Descriptor().Fields().ByName(name)
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			continue
+		}
+		projectPaths(src.Get(fd).Message(), dst.Mutable(fd).Message(), rest)
+	}
+}
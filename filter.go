@@ -0,0 +1,254 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+var (
+	ErrPublishDenied = errors.New("publish denied by filter")
+)
+
+// PublishFilterConfig holds the rules for events published through RPCs
+type PublishFilterConfig struct {
+	// Allow lists group address patterns which may be published to, optional (defaults to any)
+	// valid format: 1/2/3, 1/2/*, 1/2/10-20
+	Allow []string `mapstructure:"allow"`
+
+	// Deny lists group address patterns which must never be published to,
+	// takes precedence over [Allow]
+	Deny []string `mapstructure:"deny"`
+
+	// MaxDataSize is the maximum data size in bytes, optional (0 means unlimited)
+	MaxDataSize int `mapstructure:"maxDataSize" default:"0"`
+
+	// Events lists event types which may be published, optional (defaults to any)
+	// oneof: read|write|response
+	Events []string `mapstructure:"events"`
+}
+
+// Validate validates the PublishFilterConfig
+func (c *PublishFilterConfig) Validate() error {
+	if _, err := newPublishFilter(c); err != nil {
+		return fmt.Errorf("rpc.publishFilter: %s", err)
+	}
+
+	return nil
+}
+
+// publishFilter is the parsed form of a PublishFilterConfig
+type publishFilter struct {
+	allow       []groupAddressPattern
+	deny        []groupAddressPattern
+	maxDataSize int
+	events      map[knx.GroupCommand]bool
+}
+
+// newPublishFilter returns a *publishFilter from config or error
+func newPublishFilter(config *PublishFilterConfig) (*publishFilter, error) {
+	f := &publishFilter{
+		maxDataSize: config.MaxDataSize,
+		events:      map[knx.GroupCommand]bool{},
+	}
+
+	var err error
+	f.allow, err = parseGroupAddressPatterns(config.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("allow: %s", err)
+	}
+	f.deny, err = parseGroupAddressPatterns(config.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("deny: %s", err)
+	}
+
+	if config.MaxDataSize < 0 {
+		return nil, fmt.Errorf("invalid maxDataSize %d", config.MaxDataSize)
+	}
+
+	for _, name := range config.Events {
+		cmd, err := parseGroupCommand(name)
+		if err != nil {
+			return nil, fmt.Errorf("events: %s", err)
+		}
+		f.events[cmd] = true
+	}
+
+	return f, nil
+}
+
+// check returns an error wrapping ErrPublishDenied if event must not be published,
+// it carries the group address of event.
+func (f *publishFilter) check(event *knx.GroupEvent) error {
+	if err := f.denied(event); err != nil {
+		return newGroupAddressError(reasonPublishDenied, event.Destination, err)
+	}
+
+	return nil
+}
+
+// denied returns the reason event must not be published or nil
+func (f *publishFilter) denied(event *knx.GroupEvent) error {
+	if len(f.events) > 0 && !f.events[event.Command] {
+		return fmt.Errorf("%w: event %s is not allowed", ErrPublishDenied, event.Command)
+	}
+
+	if f.maxDataSize > 0 && len(event.Data) > f.maxDataSize {
+		return fmt.Errorf("%w: data size %d exceeds %d bytes",
+			ErrPublishDenied, len(event.Data), f.maxDataSize)
+	}
+
+	if matchGroupAddressPatterns(f.deny, event.Destination) {
+		return fmt.Errorf("%w: group address %s is denied", ErrPublishDenied, event.Destination)
+	}
+
+	if len(f.allow) > 0 && !matchGroupAddressPatterns(f.allow, event.Destination) {
+		return fmt.Errorf("%w: group address %s is not allowed", ErrPublishDenied, event.Destination)
+	}
+
+	return nil
+}
+
+// parseGroupCommand returns the knx.GroupCommand of name
+func parseGroupCommand(name string) (knx.GroupCommand, error) {
+	switch strings.ToLower(name) {
+	case "read":
+		return knx.GroupRead, nil
+	case "response":
+		return knx.GroupResponse, nil
+	case "write":
+		return knx.GroupWrite, nil
+	}
+
+	return 0, fmt.Errorf("unsupported event: %s", name)
+}
+
+// SuppressFilterConfig holds the rules for events which are never delivered to subscribers
+type SuppressFilterConfig struct {
+	// GroupAddresses lists group address patterns whose events are suppressed, optional
+	// valid format: 1/2/3, 1/2/*, 1/2/10-20
+	GroupAddresses []string `mapstructure:"groupAddresses"`
+}
+
+// Validate validates the SuppressFilterConfig
+func (c *SuppressFilterConfig) Validate() error {
+	if _, err := parseGroupAddressPatterns(c.GroupAddresses); err != nil {
+		return fmt.Errorf("rpc.suppressFilter: groupAddresses: %s", err)
+	}
+
+	return nil
+}
+
+// patternRange is an inclusive range of a single group address level
+type patternRange struct {
+	lo, hi int
+}
+
+// groupAddressPattern matches 3-level group addresses.
+// Each level may be a number, a range like 10-20 or a wildcard *.
+type groupAddressPattern [3]patternRange
+
+// groupAddressLevelMax holds the maximum value of each 3-level group address level
+var groupAddressLevelMax = [3]int{31, 7, 255}
+
+// parseGroupAddressPattern returns the groupAddressPattern of s or error
+func parseGroupAddressPattern(s string) (groupAddressPattern, error) {
+	var p groupAddressPattern
+
+	levels := strings.Split(strings.TrimSpace(s), "/")
+	if len(levels) != 3 {
+		return p, fmt.Errorf("invalid group address pattern %q", s)
+	}
+
+	for i, level := range levels {
+		if level == "*" {
+			p[i] = patternRange{0, groupAddressLevelMax[i]}
+			continue
+		}
+
+		lo, hi, isRange := strings.Cut(level, "-")
+		if !isRange {
+			hi = lo
+		}
+
+		var err error
+		p[i].lo, err = strconv.Atoi(lo)
+		if err != nil {
+			return p, fmt.Errorf("invalid group address pattern %q: %s", s, err)
+		}
+		p[i].hi, err = strconv.Atoi(hi)
+		if err != nil {
+			return p, fmt.Errorf("invalid group address pattern %q: %s", s, err)
+		}
+
+		if p[i].lo < 0 || p[i].hi > groupAddressLevelMax[i] || p[i].lo > p[i].hi {
+			return p, fmt.Errorf("invalid group address pattern %q: level %d out of range", s, i)
+		}
+	}
+
+	return p, nil
+}
+
+// parseGroupAddressPatterns returns a list of parsed patterns or error
+func parseGroupAddressPatterns(patterns []string) ([]groupAddressPattern, error) {
+	ret := []groupAddressPattern{}
+
+	for _, s := range patterns {
+		p, err := parseGroupAddressPattern(s)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret, nil
+}
+
+// match returns whether ga is matched by p
+func (p groupAddressPattern) match(ga cemi.GroupAddr) bool {
+	levels := [3]int{
+		int(ga>>11) & 0x1f,
+		int(ga>>8) & 0x7,
+		int(ga) & 0xff,
+	}
+
+	for i, level := range levels {
+		if level < p[i].lo || level > p[i].hi {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchGroupAddressPatterns returns whether ga is matched by any of patterns
+func matchGroupAddressPatterns(patterns []groupAddressPattern, ga cemi.GroupAddr) bool {
+	for _, p := range patterns {
+		if p.match(ga) {
+			return true
+		}
+	}
+
+	return false
+}
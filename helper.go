@@ -17,10 +17,13 @@ limitations under the License.
 package knxrpc
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"slices"
 
-	"connectrpc.com/connect"
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
 	"github.com/vapourismo/knx-go/knx/cemi"
 )
 
@@ -32,7 +35,8 @@ func parseGroupAddresses(addresses []string) ([]cemi.GroupAddr, error) {
 	for i, sga := range addresses {
 		ga, err := cemi.NewGroupAddrString(sga)
 		if err != nil {
-			return nil, fmt.Errorf("parse groupAddress(%d): %s", i, err)
+			return nil, fieldErrorf(fmt.Sprintf("group_addresses[%d]", i),
+				"parse groupAddress(%d): %s", i, err)
 		}
 
 		ret = append(ret, ga)
@@ -41,127 +45,140 @@ func parseGroupAddresses(addresses []string) ([]cemi.GroupAddr, error) {
 	return ret, nil
 }
 
-// registerSubscriber adds group addresses and streams into subscribers map
+// registerSubscriber adds sub to the group addresses
+// of the subscribers map. The slices of the map are replaced, never
+// modified, so dispatching may iterate them without holding the lock.
 func (s *Server) registerSubscriber(
 	addresses []cemi.GroupAddr,
-	req *v1.SubscribeRequest,
-	stream *connect.ServerStream[v1.SubscribeResponse],
+	sub *subscriber,
 ) {
 	s.m_subscribers.Lock()
 	defer s.m_subscribers.Unlock()
 
 	for _, address := range addresses {
-		// load streams slice from map
-		subs, ok := s.subscribers[address]
-		if !ok {
-			// first subscriber for this group address
-			subs = []*subscriber{}
-		}
-
-		// append ourself
-		subs = append(subs, &subscriber{
-			req:    req,
-			stream: stream,
-		})
-
-		// put subscriber slice back into the map
-		s.subscribers[address] = subs
+		// copy the slice and append ourself
+		subs := s.subscribers[address]
+		s.subscribers[address] = append(subs[:len(subs):len(subs)], sub)
 	}
 }
 
-// unregisterSubscriber removes group addresses and streams from subscribers map
+// unregisterSubscriber removes sub from the group addresses of the
+// subscribers map and waits for a pending send to it
 func (s *Server) unregisterSubscriber(
 	addresses []cemi.GroupAddr,
-	stream *connect.ServerStream[v1.SubscribeResponse],
+	sub *subscriber,
 ) {
 	s.m_subscribers.Lock()
-	defer s.m_subscribers.Unlock()
-
 	for _, address := range addresses {
-		// load subscriber slice from map
-		subs, ok := s.subscribers[address]
-		if !ok {
-			// this indicates a failure, ignore it
-			continue
+		subs := removeSubscriber(s.subscribers[address], sub)
+		if len(subs) > 0 {
+			s.subscribers[address] = subs
+		} else {
+			delete(s.subscribers, address)
 		}
+	}
+	s.m_subscribers.Unlock()
 
-		// check if we are the last subscriber, then we can simply reslice
-		if len(subs) > 0 && subs[len(subs)-1].stream == stream {
-			// drop the last element
-			subs = subs[:len(subs)-1]
+	sub.close()
+}
 
-			// delete the slice if its empty now
-			if len(subs) > 0 {
-				s.subscribers[address] = subs
-			} else {
-				delete(s.subscribers, address)
-			}
-			continue
-		}
+// registerSniffer adds sub to the sniffers slice,
+// which is replaced, never modified
+func (s *Server) registerSniffer(sub *subscriber) {
+	s.m_sniffers.Lock()
+	defer s.m_sniffers.Unlock()
 
-		// otherwise we need to find ourself in the slice and move the
-		// last one element into our position to stay within O(n).
-		for i, s := range subs {
-			if s.stream != stream {
-				continue
-			}
+	s.sniffers = append(s.sniffers[:len(s.sniffers):len(s.sniffers)], sub)
+}
 
-			// we found ourself, move the last element to our index
-			subs[i] = subs[len(subs)-1]
+// unregisterSniffer removes sub from the sniffers slice
+// and waits for a pending send to it
+func (s *Server) unregisterSniffer(sub *subscriber) {
+	s.m_sniffers.Lock()
+	s.sniffers = removeSubscriber(s.sniffers, sub)
+	s.m_sniffers.Unlock()
 
-			// and drop the last element
-			subs = subs[:len(subs)-1]
+	sub.close()
+}
 
-			break
+// listSubscribers returns the info of all subscribers and sniffers
+// ordered by their start time
+func (s *Server) listSubscribers() []*v1.SubscriberInfo {
+	subs := []*subscriber{}
+	s.m_sniffers.RLock()
+	subs = append(subs, s.sniffers...)
+	s.m_sniffers.RUnlock()
+
+	// subscribers may be registered for several group addresses
+	seen := map[*subscriber]bool{}
+	s.m_subscribers.RLock()
+	for _, addressSubs := range s.subscribers {
+		for _, sub := range addressSubs {
+			if !seen[sub] {
+				seen[sub] = true
+				subs = append(subs, sub)
+			}
 		}
+	}
+	s.m_subscribers.RUnlock()
+
+	slices.SortFunc(subs, func(a, b *subscriber) int {
+		return a.started.Compare(b.started)
+	})
 
-		// put in back in the map
-		s.subscribers[address] = subs
+	ret := make([]*v1.SubscriberInfo, 0, len(subs))
+	for _, sub := range subs {
+		ret = append(ret, sub.info())
 	}
+
+	return ret
 }
 
-// registerSniffer adds a subscriber to sniffers slice
-func (s *Server) registerSniffer(
-	req *v1.SubscribeRequest,
-	stream *connect.ServerStream[v1.SubscribeResponse],
-) {
-	s.m_sniffers.Lock()
-	defer s.m_sniffers.Unlock()
+// removeSubscriber returns a copy of subs without sub
+func removeSubscriber(subs []*subscriber, sub *subscriber) []*subscriber {
+	ret := make([]*subscriber, 0, len(subs))
+	for _, other := range subs {
+		if other != sub {
+			ret = append(ret, other)
+		}
+	}
 
-	s.sniffers = append(s.sniffers, &subscriber{
-		req:    req,
-		stream: stream,
-	})
+	return ret
 }
 
-// unregisterSniffer removes a subscriber from sniffers slice
-func (s *Server) unregisterSniffer(
-	stream *connect.ServerStream[v1.SubscribeResponse],
-) {
-	s.m_sniffers.Lock()
-	defer s.m_sniffers.Unlock()
+// registerEventListener returns a channel receiving all group events for
+// internal consumers. The returned func must be called to unregister it.
+func (s *Server) registerEventListener() (<-chan *knx.GroupEvent, func()) {
+	ch := make(chan *knx.GroupEvent, 64)
 
-	// check if we are the last subscriber, then we can simply reslice
-	if len(s.sniffers) > 0 && s.sniffers[len(s.sniffers)-1].stream == stream {
-		// drop the last element
-		s.sniffers = s.sniffers[:len(s.sniffers)-1]
+	s.m_eventListeners.Lock()
+	s.eventListeners = append(s.eventListeners, ch)
+	s.m_eventListeners.Unlock()
 
-		return
-	}
+	return ch, func() {
+		s.m_eventListeners.Lock()
+		defer s.m_eventListeners.Unlock()
 
-	// otherwise we need to find ourself in the slice and move the
-	// last one element into our position to stay within O(n).
-	for i, sub := range s.sniffers {
-		if sub.stream != stream {
-			continue
-		}
+		for i, l := range s.eventListeners {
+			if l != ch {
+				continue
+			}
 
-		// we found ourself, move the last element to our index
-		s.sniffers[i] = s.sniffers[len(s.sniffers)-1]
+			// move the last element to our index and drop the last element
+			s.eventListeners[i] = s.eventListeners[len(s.eventListeners)-1]
+			s.eventListeners = s.eventListeners[:len(s.eventListeners)-1]
 
-		// and drop the last element
-		s.sniffers = s.sniffers[:len(s.sniffers)-1]
+			return
+		}
+	}
+}
 
-		return
+// newRandomID returns a random hex id
+func newRandomID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,238 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+var (
+	ErrHistoryDisabled = errors.New("history is disabled")
+)
+
+// HistoryConfig holds the config of the in-memory telegram history
+type HistoryConfig struct {
+	// Enabled whether to record dispatched events
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// MaxEvents is the maximum amount of events kept per group address
+	MaxEvents int `mapstructure:"maxEvents" default:"1000"`
+
+	// Retention is the maximum age of kept events
+	Retention time.Duration `mapstructure:"retention" default:"24h"`
+}
+
+// Validate validates the HistoryConfig
+func (c *HistoryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxEvents <= 0 {
+		return fmt.Errorf("invalid rpc.history.maxEvents")
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("invalid rpc.history.retention")
+	}
+
+	return nil
+}
+
+// historyEntry is a recorded event
+type historyEntry struct {
+	time  time.Time
+	event *knx.GroupEvent
+}
+
+// history stores recent events per group address in memory
+type history struct {
+	config *HistoryConfig
+
+	// entries stores events by group address ordered by time
+	entries map[cemi.GroupAddr][]historyEntry
+	// m_entries synchronizes access to entries
+	m_entries sync.RWMutex
+}
+
+// newHistory returns a *history from config, nil if disabled
+func newHistory(config *HistoryConfig) *history {
+	if !config.Enabled {
+		return nil
+	}
+
+	return &history{
+		config:  config,
+		entries: map[cemi.GroupAddr][]historyEntry{},
+	}
+}
+
+// record stores event, dropping entries exceeding MaxEvents or Retention
+func (h *history) record(event *knx.GroupEvent) {
+	if h == nil {
+		return
+	}
+
+	now := time.Now()
+
+	h.m_entries.Lock()
+	defer h.m_entries.Unlock()
+
+	entries := append(h.entries[event.Destination], historyEntry{
+		time:  now,
+		event: event,
+	})
+
+	// drop oldest entries
+	drop := max(len(entries)-h.config.MaxEvents, 0)
+	for drop < len(entries) && now.Sub(entries[drop].time) > h.config.Retention {
+		drop++
+	}
+	if drop > 0 {
+		entries = append(entries[:0:0], entries[drop:]...)
+	}
+
+	h.entries[event.Destination] = entries
+}
+
+// query returns a copy of the entries of ga within [from, to)
+func (h *history) query(ga cemi.GroupAddr, from, to time.Time) ([]historyEntry, error) {
+	if h == nil {
+		return nil, ErrHistoryDisabled
+	}
+
+	h.m_entries.RLock()
+	defer h.m_entries.RUnlock()
+
+	ret := []historyEntry{}
+	for _, e := range h.entries[ga] {
+		if e.time.Before(from) || !e.time.Before(to) {
+			continue
+		}
+		if time.Since(e.time) > h.config.Retention {
+			continue
+		}
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+// historyRow is a downloadable history entry
+type historyRow struct {
+	Time            string `json:"time"`
+	GroupAddress    string `json:"group_address"`
+	PhysicalAddress string `json:"physical_address"`
+	Event           string `json:"event"`
+	Data            string `json:"data"`
+}
+
+// historyDownloadHandler serves recorded events as CSV or JSON attachment.
+// Query parameters: group_address (required, repeatable), from and to as
+// RFC3339 times (optional) and format csv|json (defaults to csv).
+func (s *Server) historyDownloadHandler(c echo.Context) error {
+	params := c.QueryParams()
+
+	addresses, err := parseGroupAddresses(params["group_address"])
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(addresses) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing group_address")
+	}
+
+	to := time.Now()
+	if len(params.Get("to")) > 0 {
+		to, err = time.Parse(time.RFC3339, params.Get("to"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("parsing 'to': %v", err))
+		}
+	}
+	from := to.Add(-s.config.RPC.History.Retention)
+	if len(params.Get("from")) > 0 {
+		from, err = time.Parse(time.RFC3339, params.Get("from"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("parsing 'from': %v", err))
+		}
+	}
+
+	format := params.Get("format")
+	if len(format) == 0 {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		return echo.NewHTTPError(http.StatusBadRequest, "unsupported format: "+format)
+	}
+
+	// collect entries of all group addresses ordered by time
+	entries := []historyEntry{}
+	for _, ga := range addresses {
+		e, err := s.history.query(ga, from, to)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		}
+		entries = append(entries, e...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.Before(entries[j].time)
+	})
+
+	rows := []historyRow{}
+	for _, e := range entries {
+		rows = append(rows, historyRow{
+			Time:            e.time.Format(time.RFC3339Nano),
+			GroupAddress:    groupAddrStrings.get(e.event.Destination),
+			PhysicalAddress: individualAddrStrings.get(e.event.Source),
+			Event:           toV1SubscribeResponse(e.event).Event.String(),
+			Data:            hex.EncodeToString(e.event.Data),
+		})
+	}
+
+	filename := fmt.Sprintf("history-%s.%s",
+		strings.ReplaceAll(addresses[0].String(), "/", "_"), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "json" {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c.Response().WriteHeader(http.StatusOK)
+		return json.NewEncoder(c.Response()).Encode(rows)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().WriteHeader(http.StatusOK)
+	w := csv.NewWriter(c.Response())
+	w.Write([]string{"time", "group_address", "physical_address", "event", "data"}) // nolint:errcheck
+	for _, row := range rows {
+		w.Write([]string{row.Time, row.GroupAddress, row.PhysicalAddress, row.Event, row.Data}) // nolint:errcheck
+	}
+	w.Flush()
+
+	return w.Error()
+}
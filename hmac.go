@@ -0,0 +1,237 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
+	v2Connect "github.com/choopm/knxrpc/knx/groupaddress/v2/v2connect"
+)
+
+// headers of signed requests
+const (
+	hmacTimestampHeader = "X-Knxrpc-Timestamp"
+	hmacNonceHeader     = "X-Knxrpc-Nonce"
+	hmacSignatureHeader = "X-Knxrpc-Signature"
+)
+
+// hmacMaxBodySize limits the size of signed request bodies
+const hmacMaxBodySize = 1 << 20
+
+var (
+	ErrSignatureMissing  = errors.New("missing request signature")
+	ErrSignatureInvalid  = errors.New("invalid request signature")
+	ErrSignatureStale    = errors.New("stale request signature")
+	ErrSignatureReplayed = errors.New("replayed request signature")
+)
+
+// HMACConfig holds the config of HMAC signed publish requests
+type HMACConfig struct {
+	// Enabled whether to sign (client) or accept signed (server) publish requests
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Required whether the server rejects unsigned publish requests
+	Required bool `mapstructure:"required" default:"false"`
+
+	// Name identifies the key in logs
+	Name string `mapstructure:"name" default:"hmac"`
+
+	// SecretKey is the shared plaintext key to sign with, required if [Enabled]
+	SecretKey string `mapstructure:"secretKey"`
+
+	// SecretKeyFile is a file to read SecretKey from, optional
+	SecretKeyFile string `mapstructure:"secretKeyFile"`
+
+	// Role of signed requests, oneof: admin|writer|reader
+	Role Role `mapstructure:"role" default:"writer"`
+
+	// MaxSkew is the maximum age of a signature, nonces are remembered twice as long
+	MaxSkew time.Duration `mapstructure:"maxSkew" default:"5m"`
+}
+
+// Validate validates the HMACConfig
+func (c *HMACConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.SecretKey) == 0 {
+		return fmt.Errorf("missing auth.hmac.secretKey")
+	}
+	if isHashedSecret(c.SecretKey) {
+		return fmt.Errorf("auth.hmac.secretKey must be plaintext")
+	}
+	if c.Role.level() == 0 {
+		return fmt.Errorf("invalid auth.hmac.role: %s", c.Role)
+	}
+	if c.MaxSkew <= 0 {
+		return fmt.Errorf("invalid auth.hmac.maxSkew")
+	}
+
+	return nil
+}
+
+// hmacSigned returns whether requests to procedure are signed
+func hmacSigned(procedure string) bool {
+	return procedure == v1Connect.GroupAddressServicePublishProcedure ||
+		procedure == v2Connect.GroupAddressServicePublishProcedure
+}
+
+// hmacSignature returns the hex encoded HMAC-SHA256 using key of
+// timestamp, nonce, procedure and body separated by newlines.
+func hmacSignature(key, timestamp, nonce, procedure string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + "\n" + nonce + "\n" + procedure + "\n")) // nolint:errcheck
+	mac.Write(body)                                                       // nolint:errcheck
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hmacVerifier verifies signed requests and rejects replayed nonces
+type hmacVerifier struct {
+	config *HMACConfig
+	key    *apiKey
+
+	// nonces stores the expiry of seen nonces
+	nonces map[string]time.Time
+	// m_nonces synchronizes access to nonces
+	m_nonces sync.Mutex
+}
+
+// newHMACVerifier returns a *hmacVerifier of config, nil if disabled
+func newHMACVerifier(config *HMACConfig) *hmacVerifier {
+	if !config.Enabled {
+		return nil
+	}
+
+	return &hmacVerifier{
+		config: config,
+		key: &apiKey{
+			config: &APIKeyConfig{
+				Name: config.Name,
+				Role: config.Role,
+			},
+		},
+		nonces: map[string]time.Time{},
+	}
+}
+
+// verify checks the signature of req using secret, restoring its body.
+// It returns ErrSignatureMissing if req is not signed.
+func (v *hmacVerifier) verify(req *http.Request, procedure, secret string) error {
+	timestamp := req.Header.Get(hmacTimestampHeader)
+	nonce := req.Header.Get(hmacNonceHeader)
+	signature := req.Header.Get(hmacSignatureHeader)
+	if len(signature) == 0 {
+		return ErrSignatureMissing
+	}
+	if len(nonce) < 16 || len(nonce) > 128 {
+		return fmt.Errorf("%w: nonce must have 16 to 128 characters", ErrSignatureInvalid)
+	}
+
+	// reject stale signatures before reading the body
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrSignatureInvalid)
+	}
+	now := time.Now()
+	if d := now.Sub(time.Unix(sec, 0)); d > v.config.MaxSkew || d < -v.config.MaxSkew {
+		return ErrSignatureStale
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, hmacMaxBodySize+1))
+	if err != nil {
+		return fmt.Errorf("reading body: %s", err)
+	}
+	if len(body) > hmacMaxBodySize {
+		return fmt.Errorf("%w: body too large", ErrSignatureInvalid)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	expected := hmacSignature(secret, timestamp, nonce, procedure, body)
+	if len(secret) == 0 || !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return ErrSignatureInvalid
+	}
+
+	// only valid signatures consume their nonce
+	v.m_nonces.Lock()
+	defer v.m_nonces.Unlock()
+	for n, expires := range v.nonces {
+		if now.After(expires) {
+			delete(v.nonces, n)
+		}
+	}
+	if _, ok := v.nonces[nonce]; ok {
+		return ErrSignatureReplayed
+	}
+	v.nonces[nonce] = now.Add(2 * v.config.MaxSkew)
+
+	return nil
+}
+
+// hmacTransport is a http.RoundTripper signing publish requests
+type hmacTransport struct {
+	config *HMACConfig
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *hmacTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !hmacSigned(req.URL.Path) {
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close() // nolint:errcheck
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set(hmacTimestampHeader, timestamp)
+	req.Header.Set(hmacNonceHeader, hex.EncodeToString(nonce))
+	req.Header.Set(hmacSignatureHeader, hmacSignature(t.config.SecretKey,
+		timestamp, hex.EncodeToString(nonce), req.URL.Path, body))
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+)
+
+// IdempotencyHeader is the request header carrying an idempotency key
+const IdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyConfig holds the config for deduplicating retried publishes
+type IdempotencyConfig struct {
+	// Window is the duration to remember idempotency keys, 0 disables deduplication
+	Window time.Duration `mapstructure:"window" default:"5m"`
+}
+
+// Validate validates the IdempotencyConfig
+func (c *IdempotencyConfig) Validate() error {
+	if c.Window < 0 {
+		return fmt.Errorf("invalid rpc.idempotency.window")
+	}
+
+	return nil
+}
+
+// idempotencyEntry stores the result of a request using an idempotency key
+type idempotencyEntry struct {
+	// done is closed once res and err are set
+	done chan struct{}
+
+	// expires is set once done, guarded by m_idempotency
+	expires time.Time
+
+	res *v1.PublishResponse
+	err error
+}
+
+// idempotencyKey returns the idempotency key of req, preferring the field over the header
+func idempotencyKey(req *connect.Request[v1.PublishRequest]) string {
+	if len(req.Msg.IdempotencyKey) > 0 {
+		return req.Msg.IdempotencyKey
+	}
+
+	return req.Header().Get(IdempotencyHeader)
+}
+
+// deduplicate runs fn once per identity and key within rpc.idempotency.window
+// and returns the original result to duplicates. Failed results are forgotten
+// so that retries are executed again.
+func (s *Server) deduplicate(
+	ctx context.Context,
+	identity string,
+	key string,
+	fn func() (*v1.PublishResponse, error),
+) (*v1.PublishResponse, error) {
+	window := s.config.RPC.Idempotency.Window
+	if window == 0 || len(key) == 0 {
+		return fn()
+	}
+
+	// keys are scoped per identity
+	mapKey := identity + "\x00" + key
+
+	s.m_idempotency.Lock()
+	s.pruneIdempotency(time.Now())
+	e, duplicate := s.idempotency[mapKey]
+	if !duplicate {
+		e = &idempotencyEntry{
+			done: make(chan struct{}),
+		}
+		s.idempotency[mapKey] = e
+	}
+	s.m_idempotency.Unlock()
+
+	if duplicate {
+		// wait for the original request
+		select {
+		case <-ctx.Done():
+			return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+		case <-e.done:
+			return e.res, e.err
+		}
+	}
+
+	e.res, e.err = fn()
+
+	s.m_idempotency.Lock()
+	e.expires = time.Now().Add(window)
+	if e.err != nil {
+		delete(s.idempotency, mapKey)
+	}
+	s.m_idempotency.Unlock()
+	close(e.done)
+
+	return e.res, e.err
+}
+
+// pruneIdempotency removes expired entries, m_idempotency must be held
+func (s *Server) pruneIdempotency(now time.Time) {
+	for key, e := range s.idempotency {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			delete(s.idempotency, key)
+		}
+	}
+}
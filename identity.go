@@ -0,0 +1,151 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/authn"
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// identityAnonymous is the identity of requests if rpc.auth is disabled
+	identityAnonymous = "anonymous"
+	// identityStatic is the identity of requests using rpc.auth.secretKey
+	identityStatic = "static"
+)
+
+// AuditConfig holds the audit trail config
+type AuditConfig struct {
+	// Enabled whether to log every call of a procedure requiring the
+	// writer or admin role with the identity of the caller
+	Enabled bool `mapstructure:"enabled" default:"false"`
+}
+
+// Validate validates the AuditConfig
+func (c *AuditConfig) Validate() error {
+	return nil
+}
+
+// identityFromContext returns the name of the authenticated identity of ctx
+func identityFromContext(ctx context.Context) string {
+	return identityName(authn.GetInfo(ctx))
+}
+
+// identityName returns the name of the identity info returned by
+// authenticateRPC: the API key name (ldap:<username> for directory users),
+// tenant:<name> for tenants, static for rpc.auth.secretKey or anonymous.
+func identityName(info any) string {
+	switch info := info.(type) {
+	case *apiKey:
+		return info.config.Name
+	case *tenant:
+		return "tenant:" + info.name()
+	}
+
+	return identityAnonymous
+}
+
+// identityInterceptor is a connect.Interceptor recording calls by identity
+// as metric and audit trail.
+type identityInterceptor struct {
+	s *Server
+
+	// requests counts calls by identity, procedure and code, nil if disabled
+	requests otelmetric.Int64Counter
+}
+
+// newIdentityInterceptor returns a fresh *identityInterceptor or error
+func (s *Server) newIdentityInterceptor() (*identityInterceptor, error) {
+	i := &identityInterceptor{s: s}
+	if s.meterProvider == nil {
+		return i, nil
+	}
+
+	var err error
+	i.requests, err = s.meterProvider.Meter("github.com/choopm/knxrpc").Int64Counter(
+		"knxrpc.rpc.requests",
+		otelmetric.WithDescription("Handled RPCs by identity, procedure and code"),
+		otelmetric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// record records a finished call to procedure by the identity of ctx
+func (i *identityInterceptor) record(ctx context.Context, procedure, peer string, err error) {
+	identity := identityFromContext(ctx)
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+
+	if i.requests != nil {
+		i.requests.Add(ctx, 1, otelmetric.WithAttributes(
+			attribute.String("identity", identity),
+			attribute.String("procedure", procedure),
+			attribute.String("code", code),
+		))
+	}
+
+	if i.s.config.RPC.Audit.Enabled && requiredRole(procedure) != RoleReader {
+		ev := i.s.log.Info()
+		var cerr *connect.Error
+		if errors.As(err, &cerr) {
+			ev = ev.Str("error", cerr.Message())
+		}
+		ev.Str("identity", identity).
+			Str("procedure", procedure).
+			Str("peer", peer).
+			Str("code", code).
+			Msg("audit")
+	}
+}
+
+// WrapUnary implements [connect.Interceptor]
+func (i *identityInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		if !req.Spec().IsClient {
+			i.record(ctx, req.Spec().Procedure,
+				i.s.trustedProxies.peerAddr(req.Peer().Addr, req.Header()), err)
+		}
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements [connect.Interceptor] with a no-op.
+func (i *identityInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements [connect.Interceptor]
+func (i *identityInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		err := next(ctx, conn)
+		i.record(ctx, conn.Spec().Procedure,
+			i.s.trustedProxies.peerAddr(conn.Peer().Addr, conn.RequestHeader()), err)
+		return err
+	}
+}
@@ -18,13 +18,20 @@ package knxrpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v2 "github.com/choopm/knxrpc/knx/groupaddress/v2"
 	"github.com/rs/zerolog"
 	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/knxnet"
 	"github.com/vapourismo/knx-go/knx/util"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // knxLogHandler logs everything to trace level
@@ -51,13 +58,16 @@ func (s *knxLogHandler) Printf(format string, args ...interface{}) {
 
 // connectTunnel connects and sets up the KNX tunnel
 func (s *Server) connectTunnel() (err error) {
-	// build host:port
-	hostPort := fmt.Sprintf("%s:%d",
-		s.config.KNX.GatwewayHost,
-		s.config.KNX.GatwewayPort)
+	// relay IPv6 and binding to knx.localAddress or knx.interface
+	hostPort, relay, err := relayGateway(&s.config.KNX)
+	if err != nil {
+		return fmt.Errorf("connect tunnel: %s", err)
+	}
+	s.relay = relay
 
-	// Connect to the gateway.
-	s.tunnel, err = knx.NewGroupTunnel(hostPort, knx.TunnelConfig{
+	// Connect to the gateway using the data link layer,
+	// group and management frames are both handled by us.
+	s.tunnel, err = knx.NewTunnel(hostPort, knxnet.TunnelLayerData, knx.TunnelConfig{
 		ResendInterval:    knx.DefaultTunnelConfig.ResendInterval,
 		HeartbeatInterval: knx.DefaultTunnelConfig.HeartbeatInterval,
 		ResponseTimeout:   s.config.KNX.Timeout,
@@ -65,15 +75,36 @@ func (s *Server) connectTunnel() (err error) {
 		UseTCP:            s.config.KNX.UseTCP,
 	})
 	if err != nil {
+		if relay != nil {
+			relay.Close() // nolint:errcheck
+		}
 		return fmt.Errorf("connect tunnel: %s", err)
 	}
-	// s.tunnel.Close() is handled at the end of [Start]
+	s.connected = time.Now()
+	// s.tunnel.Close() and s.relay.Close() are handled at the end of [Start]
 
 	return nil
 }
 
-// busMessageReader starts the message reading or error
+// busMessageReader starts the message reading or error.
+// It errors after knx.inactivityTimeout without bus activity and
+// keeps notifying the systemd watchdog while reading.
 func (s *Server) busMessageReader(ctx context.Context) error {
+	inactivity := s.config.KNX.InactivityTimeout
+	lastActivity := time.Now()
+
+	// check at half the systemd watchdog or inactivity timeout
+	var watchdog <-chan time.Time
+	interval := sdWatchdogInterval() / 2
+	if inactivity > 0 && (interval == 0 || inactivity/2 < interval) {
+		interval = inactivity / 2
+	}
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		watchdog = ticker.C
+	}
+
 	// infinite reader loop
 	for {
 		select {
@@ -81,52 +112,237 @@ func (s *Server) busMessageReader(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 
-		// pass any event to message dispatcher
-		case event := <-s.tunnel.Inbound():
-			if err := s.dispatchEvent(&event); err != nil {
+		// a dead bus connection stops notifying systemd
+		case <-watchdog:
+			if inactivity > 0 && time.Since(lastActivity) > inactivity {
+				return fmt.Errorf("no bus activity for %s", inactivity)
+			}
+			if err := sdNotify("WATCHDOG=1"); err != nil {
+				s.log.Warn().
+					Err(err).
+					Msg("unable to notify systemd watchdog")
+			}
+
+		// pass any message to message dispatcher
+		case msg, ok := <-s.tunnel.Inbound():
+			if !ok {
+				return errors.New("knx tunnel inbound closed")
+			}
+			lastActivity = time.Now()
+			if err := s.dispatchMessage(msg); err != nil {
 				return err
 			}
 		}
 	}
 }
 
+// dispatchMessage dispatches a cEMI message either as group event
+// or as management frame to device listeners.
+func (s *Server) dispatchMessage(msg cemi.Message) error {
+	ind, ok := msg.(*cemi.LDataInd)
+	if !ok {
+		// confirmations of our own requests are not dispatched
+		if con, ok := msg.(*cemi.LDataCon); ok {
+			s.tunnelAddress.confirmed(con)
+		}
+		return nil
+	}
+
+	app, ok := ind.Data.(*cemi.AppData)
+	if !ok || !ind.Control2.IsGroupAddr() || !app.Command.IsGroupCommand() {
+		// transport control or management frame
+		s.dispatchToDeviceListeners(&ind.LData)
+		return nil
+	}
+
+	if s.repeats.check(ind, app) {
+		s.log.Trace().
+			Str("group-address", cemi.GroupAddr(ind.Destination).String()).
+			Msg("suppressed repeated telegram")
+		return nil
+	}
+	s.bridge.forwardOut(&ind.LData)
+
+	return s.dispatchEvent(&knx.GroupEvent{
+		Command:     knx.GroupCommand(app.Command),
+		Source:      ind.Source,
+		Destination: cemi.GroupAddr(ind.Destination),
+		Data:        app.Data,
+	})
+}
+
+// dispatchToEventListeners sends the event to internal event listeners
+func (s *Server) dispatchToEventListeners(event *knx.GroupEvent) {
+	s.m_eventListeners.RLock()
+	defer s.m_eventListeners.RUnlock()
+
+	for _, l := range s.eventListeners {
+		select {
+		case l <- event:
+		default:
+			s.log.Warn().
+				Str("group-address", event.Destination.String()).
+				Msg("event listener is full, dropping event")
+		}
+	}
+}
+
+// sendGroupEvent writes event to the bus unless denied by publishFilter
+func (s *Server) sendGroupEvent(event *knx.GroupEvent) error {
+	if err := s.reloadable().publishFilter.check(event); err != nil {
+		return err
+	}
+
+	// the tunnel address is the default source
+	ldata := buildGroupOutbound(event)
+	s.tunnelAddress.fill(&ldata)
+	event.Source = ldata.Source
+	if err := s.tunnel.Send(&cemi.LDataReq{LData: ldata}); err != nil {
+		return err
+	}
+	s.bridge.forwardOut(&ldata)
+
+	return nil
+}
+
+// buildGroupOutbound constructs the L_Data frame for group communication
+func buildGroupOutbound(event *knx.GroupEvent) cemi.LData {
+	ldata := cemi.LData{
+		Control1: cemi.Control1NoRepeat | cemi.Control1NoSysBroadcast |
+			cemi.Control1WantAck | cemi.Control1Prio(cemi.PrioLow),
+		Control2:    cemi.Control2GroupAddr | cemi.Control2Hops(6),
+		Source:      event.Source,
+		Destination: uint16(event.Destination),
+		Data: &cemi.AppData{
+			Command: cemi.APCI(event.Command),
+			Data:    event.Data,
+		},
+	}
+
+	if len(event.Data) <= 15 {
+		ldata.Control1 |= cemi.Control1StdFrame
+	}
+
+	return ldata
+}
+
 // dispatchEvent dispatches an event to connected streams
+// unless its group address is suppressed.
 func (s *Server) dispatchEvent(event *knx.GroupEvent) error {
-	if err := s.dispatchToSubscribers(event); err != nil {
+	if matchGroupAddressPatterns(s.reloadable().suppressed, event.Destination) {
+		s.log.Trace().
+			Str("group-address", event.Destination.String()).
+			Msg("suppressed event")
+		return nil
+	}
+
+	s.history.record(event)
+	s.recordLastEvent(event)
+
+	// the response is shared by all streams as sending only reads it
+	resp := &lazyResponse{
+		server:   s,
+		event:    event,
+		sequence: s.sequence.Add(1),
+		received: time.Now(),
+	}
+	entry := resumeEntry{
+		event:    event,
+		sequence: resp.sequence,
+		received: resp.received,
+	}
+	s.resume.record(entry)
+	s.replay.record(entry)
+	if err := s.dispatchToSubscribers(event, resp); err != nil {
 		return err
 	}
-	if err := s.dispatchToSniffers(event); err != nil {
+	if err := s.dispatchToSniffers(event, resp); err != nil {
 		return err
 	}
+	s.dispatchToEventListeners(event)
 
 	return nil
 }
 
-// dispatchToSubscribers sends the event to subscriber streams
-func (s *Server) dispatchToSubscribers(event *knx.GroupEvent) error {
-	s.m_subscribers.Lock()
-	defer s.m_subscribers.Unlock()
+// lazyResponse builds the v1.SubscribeResponse and v2.SubscribeResponse
+// of event on first use
+type lazyResponse struct {
+	server   *Server
+	event    *knx.GroupEvent
+	sequence uint64
+	received time.Time
+	resp     *v1.SubscribeResponse
+	respV2   *v2.SubscribeResponse
+
+	// projections of resp and respV2 by fieldMask.key
+	projections map[string]proto.Message
+}
+
+// get returns the response, building it if needed
+func (l *lazyResponse) get() *v1.SubscribeResponse {
+	if l.resp == nil {
+		l.resp = toV1SubscribeResponse(l.event)
+		l.resp.Value = l.server.decodeEventValue(l.event)
+		l.resp.ResumeToken = l.server.resume.token(l.sequence)
+	}
+
+	return l.resp
+}
+
+// getV2 returns the v2 response, building it if needed
+func (l *lazyResponse) getV2() *v2.SubscribeResponse {
+	if l.respV2 == nil {
+		entry := l.server.reloadable().directory[l.event.Destination]
+		telegram := toV2Telegram(l.event, entry)
+		telegram.Sequence = l.sequence
+		telegram.Time = timestamppb.New(l.received)
+		telegram.ResumeToken = l.server.resume.token(l.sequence)
+		l.respV2 = &v2.SubscribeResponse{Telegram: telegram}
+	}
+
+	return l.respV2
+}
 
+// project returns resp projected by mask, once per mask
+func (l *lazyResponse) project(resp proto.Message, mask *fieldMask) proto.Message {
+	if projected, ok := l.projections[mask.key]; ok {
+		return projected
+	}
+	if l.projections == nil {
+		l.projections = map[string]proto.Message{}
+	}
+	projected := mask.project(resp)
+	l.projections[mask.key] = projected
+
+	return projected
+}
+
+// dispatchToSubscribers sends the event to subscriber streams
+func (s *Server) dispatchToSubscribers(event *knx.GroupEvent, lazy *lazyResponse) error {
+	// the slice is never modified, send without holding the lock
+	s.m_subscribers.RLock()
 	subs, ok := s.subscribers[event.Destination]
+	s.m_subscribers.RUnlock()
 	if !ok {
 		// no subscribers for this group address
 		return nil
 	}
 
-	resp := toV1SubscribeResponse(event)
+	resp := lazy.get()
 
 	for _, sub := range subs {
 		if sub.req.Event != v1.Event_EVENT_UNSPECIFIED &&
 			sub.req.Event != resp.Event {
 			// this subscriber is not interested in this kind of event
+			sub.filtered.Add(1)
 			continue
 		}
 
-		err := sub.stream.Send(resp)
+		err := sub.send(lazy)
 		if err != nil {
 			s.log.Error().
 				Err(err).
-				Str("peer", sub.stream.Conn().Peer().Addr).
+				Str("peer", sub.peer).
 				Msg("unable to send response to subscriber")
 			continue
 		}
@@ -136,29 +352,36 @@ func (s *Server) dispatchToSubscribers(event *knx.GroupEvent) error {
 }
 
 // dispatchToSniffers sends the event to sniffer streams
-func (s *Server) dispatchToSniffers(event *knx.GroupEvent) error {
-	s.m_sniffers.Lock()
-	defer s.m_sniffers.Unlock()
-
-	if len(s.sniffers) == 0 {
+func (s *Server) dispatchToSniffers(event *knx.GroupEvent, lazy *lazyResponse) error {
+	// the slice is never modified, send without holding the lock
+	s.m_sniffers.RLock()
+	sniffers := s.sniffers
+	s.m_sniffers.RUnlock()
+	if len(sniffers) == 0 {
 		// no sniffers connected
 		return nil
 	}
 
-	resp := toV1SubscribeResponse(event)
+	resp := lazy.get()
 
-	for _, sniffer := range s.sniffers {
+	for _, sniffer := range sniffers {
+		if !sniffer.tenant.allows(event.Destination) {
+			// this sniffer belongs to a tenant not allowed to see this group address
+			sniffer.filtered.Add(1)
+			continue
+		}
 		if sniffer.req.Event != v1.Event_EVENT_UNSPECIFIED &&
 			sniffer.req.Event != resp.Event {
 			// this sniffer is not interested in this kind of event
+			sniffer.filtered.Add(1)
 			continue
 		}
 
-		err := sniffer.stream.Send(resp)
+		err := sniffer.send(lazy)
 		if err != nil {
 			s.log.Error().
 				Err(err).
-				Str("peer", sniffer.stream.Conn().Peer().Addr).
+				Str("peer", sniffer.peer).
 				Msg("unable to send response to sniffer")
 			continue
 		}
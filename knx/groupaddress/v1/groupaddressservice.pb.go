@@ -12,6 +12,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/visibility"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -76,6 +77,57 @@ func (Event) EnumDescriptor() ([]byte, []int) {
 	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{0}
 }
 
+type SubscribeUnaryReason int32
+
+const (
+	SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_UNSPECIFIED SubscribeUnaryReason = 0
+	// max_messages were received
+	SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_MAX_MESSAGES SubscribeUnaryReason = 1
+	// for or the request deadline passed
+	SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_TIMEOUT SubscribeUnaryReason = 2
+)
+
+// Enum value maps for SubscribeUnaryReason.
+var (
+	SubscribeUnaryReason_name = map[int32]string{
+		0: "SUBSCRIBE_UNARY_REASON_UNSPECIFIED",
+		1: "SUBSCRIBE_UNARY_REASON_MAX_MESSAGES",
+		2: "SUBSCRIBE_UNARY_REASON_TIMEOUT",
+	}
+	SubscribeUnaryReason_value = map[string]int32{
+		"SUBSCRIBE_UNARY_REASON_UNSPECIFIED":  0,
+		"SUBSCRIBE_UNARY_REASON_MAX_MESSAGES": 1,
+		"SUBSCRIBE_UNARY_REASON_TIMEOUT":      2,
+	}
+)
+
+func (x SubscribeUnaryReason) Enum() *SubscribeUnaryReason {
+	p := new(SubscribeUnaryReason)
+	*p = x
+	return p
+}
+
+func (x SubscribeUnaryReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscribeUnaryReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscribeUnaryReason) Type() protoreflect.EnumType {
+	return &file_knx_groupaddress_v1_groupaddressservice_proto_enumTypes[1]
+}
+
+func (x SubscribeUnaryReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscribeUnaryReason.Descriptor instead.
+func (SubscribeUnaryReason) EnumDescriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{1}
+}
+
 type PublishRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// group_address to target the message to, required
@@ -87,7 +139,21 @@ type PublishRequest struct {
 	// type of bus message, optional (defaults to EVENT_WRITE)
 	Event Event `protobuf:"varint,3,opt,name=event,proto3,enum=knx.groupaddress.v1.Event" json:"event,omitempty"`
 	// actual data to write to the bus, required for EVENT_WRITE
-	Data          []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// idempotency_key deduplicates retried requests within rpc.idempotency.window, optional
+	// may also be provided using the Idempotency-Key header
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// delay the publish by this duration string, optional
+	Delay string `protobuf:"bytes,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	// publish at this RFC3339 time, optional (mutually exclusive with delay)
+	// valid format: 2024-01-02T15:04:05Z
+	At string `protobuf:"bytes,7,opt,name=at,proto3" json:"at,omitempty"`
+	// value to encode as data using dpt, optional (mutually exclusive with data)
+	// supports boolean and numeric datapoint types, e.g.: 21.5
+	Value string `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	// datapoint type to encode value with, optional (defaults to the configured dpt)
+	// valid format: 9.001
+	Dpt           string `protobuf:"bytes,9,opt,name=dpt,proto3" json:"dpt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -150,8 +216,45 @@ func (x *PublishRequest) GetData() []byte {
 	return nil
 }
 
+func (x *PublishRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetDelay() string {
+	if x != nil {
+		return x.Delay
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetDpt() string {
+	if x != nil {
+		return x.Dpt
+	}
+	return ""
+}
+
 type PublishResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// scheduled_id identifies a scheduled publish if delay or at was given
+	ScheduledId   string `protobuf:"bytes,1,opt,name=scheduled_id,json=scheduledId,proto3" json:"scheduled_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,13 +289,29 @@ func (*PublishResponse) Descriptor() ([]byte, []int) {
 	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *PublishResponse) GetScheduledId() string {
+	if x != nil {
+		return x.ScheduledId
+	}
+	return ""
+}
+
 type SubscribeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// group_addresses to subscribe to, optional (defaults to any group_adresses)
 	// valid format: 1/2/3
 	GroupAddresses []string `protobuf:"bytes,1,rep,name=group_addresses,json=groupAddresses,proto3" json:"group_addresses,omitempty"`
 	// events to subscribe to, optional (defaults to EVENT_UNSPECIFIED meaning any)
-	Event         Event `protobuf:"varint,2,opt,name=event,proto3,enum=knx.groupaddress.v1.Event" json:"event,omitempty"`
+	Event Event `protobuf:"varint,2,opt,name=event,proto3,enum=knx.groupaddress.v1.Event" json:"event,omitempty"`
+	// fields of the responses to populate, optional (defaults to all fields)
+	// paths are relative to SubscribeResponse, e.g.: group_address,value
+	Fields *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"`
+	// resume_from is the resume_token of the last received message, optional
+	// buffered messages after it are delivered before any new message
+	ResumeFrom string `protobuf:"bytes,4,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	// replay_last delivers the recent messages of each group address
+	// before any new message, optional (requires rpc.replay, exclusive with resume_from)
+	ReplayLast    *ReplayLast `protobuf:"bytes,5,opt,name=replay_last,json=replayLast,proto3" json:"replay_last,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -241,19 +360,101 @@ func (x *SubscribeRequest) GetEvent() Event {
 	return Event_EVENT_UNSPECIFIED
 }
 
+func (x *SubscribeRequest) GetFields() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetReplayLast() *ReplayLast {
+	if x != nil {
+		return x.ReplayLast
+	}
+	return nil
+}
+
+type ReplayLast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count of the most recent messages per group address, optional
+	// at least one of count or within is required
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// within is a duration string limiting the messages to the recent past, optional
+	// valid format: 10m
+	Within        string `protobuf:"bytes,2,opt,name=within,proto3" json:"within,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayLast) Reset() {
+	*x = ReplayLast{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayLast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayLast) ProtoMessage() {}
+
+func (x *ReplayLast) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayLast.ProtoReflect.Descriptor instead.
+func (*ReplayLast) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplayLast) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReplayLast) GetWithin() string {
+	if x != nil {
+		return x.Within
+	}
+	return ""
+}
+
 type SubscribeResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	GroupAddress    string                 `protobuf:"bytes,1,opt,name=group_address,json=groupAddress,proto3" json:"group_address,omitempty"`
 	PhysicalAddress string                 `protobuf:"bytes,2,opt,name=physical_address,json=physicalAddress,proto3" json:"physical_address,omitempty"`
 	Event           Event                  `protobuf:"varint,3,opt,name=event,proto3,enum=knx.groupaddress.v1.Event" json:"event,omitempty"`
 	Data            []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// value is data decoded using the configured dpt of group_address, if any
+	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// resume_token identifies this message to resume a subscription after it,
+	// set if rpc.resume is enabled
+	ResumeToken   string `protobuf:"bytes,6,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeResponse) Reset() {
 	*x = SubscribeResponse{}
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[3]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -265,7 +466,7 @@ func (x *SubscribeResponse) String() string {
 func (*SubscribeResponse) ProtoMessage() {}
 
 func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[3]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +479,7 @@ func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeResponse) Descriptor() ([]byte, []int) {
-	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{3}
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SubscribeResponse) GetGroupAddress() string {
@@ -309,19 +510,37 @@ func (x *SubscribeResponse) GetData() []byte {
 	return nil
 }
 
+func (x *SubscribeResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 type SubscribeUnaryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// wrapped SubscribeRequest
 	SubscribeRequest *SubscribeRequest `protobuf:"bytes,1,opt,name=subscribe_request,json=subscribeRequest,proto3" json:"subscribe_request,omitempty"`
 	// suscribe for this duration string, optional (if missing, will wait and return the first message)
-	For           string `protobuf:"bytes,3,opt,name=for,proto3" json:"for,omitempty"`
+	// the request deadline is honoured as well, messages received so far are returned
+	For string `protobuf:"bytes,3,opt,name=for,proto3" json:"for,omitempty"`
+	// max_messages returns once this many messages were received, optional
+	// (defaults to 1 if for is missing, unlimited otherwise)
+	MaxMessages   uint32 `protobuf:"varint,4,opt,name=max_messages,json=maxMessages,proto3" json:"max_messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeUnaryRequest) Reset() {
 	*x = SubscribeUnaryRequest{}
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[4]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -333,7 +552,7 @@ func (x *SubscribeUnaryRequest) String() string {
 func (*SubscribeUnaryRequest) ProtoMessage() {}
 
 func (x *SubscribeUnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[4]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -346,7 +565,7 @@ func (x *SubscribeUnaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeUnaryRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeUnaryRequest) Descriptor() ([]byte, []int) {
-	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{4}
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SubscribeUnaryRequest) GetSubscribeRequest() *SubscribeRequest {
@@ -363,16 +582,25 @@ func (x *SubscribeUnaryRequest) GetFor() string {
 	return ""
 }
 
+func (x *SubscribeUnaryRequest) GetMaxMessages() uint32 {
+	if x != nil {
+		return x.MaxMessages
+	}
+	return 0
+}
+
 type SubscribeUnaryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*SubscribeResponse   `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Messages []*SubscribeResponse   `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// reason the subscription ended
+	Reason        SubscribeUnaryReason `protobuf:"varint,2,opt,name=reason,proto3,enum=knx.groupaddress.v1.SubscribeUnaryReason" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeUnaryResponse) Reset() {
 	*x = SubscribeUnaryResponse{}
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[5]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -384,7 +612,7 @@ func (x *SubscribeUnaryResponse) String() string {
 func (*SubscribeUnaryResponse) ProtoMessage() {}
 
 func (x *SubscribeUnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[5]
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +625,7 @@ func (x *SubscribeUnaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeUnaryResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeUnaryResponse) Descriptor() ([]byte, []int) {
-	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{5}
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SubscribeUnaryResponse) GetMessages() []*SubscribeResponse {
@@ -407,40 +635,1765 @@ func (x *SubscribeUnaryResponse) GetMessages() []*SubscribeResponse {
 	return nil
 }
 
-var File_knx_groupaddress_v1_groupaddressservice_proto protoreflect.FileDescriptor
+func (x *SubscribeUnaryResponse) GetReason() SubscribeUnaryReason {
+	if x != nil {
+		return x.Reason
+	}
+	return SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_UNSPECIFIED
+}
 
-const file_knx_groupaddress_v1_groupaddressservice_proto_rawDesc = "" +
-	"\n" +
-	"-knx/groupaddress/v1/groupaddressservice.proto\x12\x13knx.groupaddress.v1\x1a\x1bgoogle/api/visibility.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xa2\x02\n" +
-	"\x0ePublishRequest\x12(\n" +
-	"\rgroup_address\x18\x01 \x01(\tB\x03\xe0A\x02R\fgroupAddress\x12.\n" +
-	"\x10physical_address\x18\x02 \x01(\tB\x03\xe0A\x01R\x0fphysicalAddress\x125\n" +
-	"\x05event\x18\x03 \x01(\x0e2\x1a.knx.groupaddress.v1.EventB\x03\xe0A\x01R\x05event\x12\x17\n" +
-	"\x04data\x18\x04 \x01(\fB\x03\xe0A\x01R\x04data:f\x92Ac2a{ \"group_address\": \"1/2/3\", \"physical_address\": \"0.0.0\", \"event\": \"EVENT_WRITE\", \"data\": \"AQo=\" }\"\x11\n" +
-	"\x0fPublishResponse\"\xc5\x01\n" +
-	"\x10SubscribeRequest\x12,\n" +
-	"\x0fgroup_addresses\x18\x01 \x03(\tB\x03\xe0A\x01R\x0egroupAddresses\x125\n" +
-	"\x05event\x18\x02 \x01(\x0e2\x1a.knx.groupaddress.v1.EventB\x03\xe0A\x01R\x05event:L\x92AI2G{ \"group_addresses\": [\"1/2/3\", \"4/5/6\"], \"event\": \"EVENT_UNSPECIFIED\" }\"\xa9\x01\n" +
-	"\x11SubscribeResponse\x12#\n" +
-	"\rgroup_address\x18\x01 \x01(\tR\fgroupAddress\x12)\n" +
-	"\x10physical_address\x18\x02 \x01(\tR\x0fphysicalAddress\x120\n" +
-	"\x05event\x18\x03 \x01(\x0e2\x1a.knx.groupaddress.v1.EventR\x05event\x12\x12\n" +
-	"\x04data\x18\x04 \x01(\fR\x04data\"\x97\x02\n" +
-	"\x15SubscribeUnaryRequest\x12W\n" +
-	"\x11subscribe_request\x18\x01 \x01(\v2%.knx.groupaddress.v1.SubscribeRequestB\x03\xe0A\x01R\x10subscribeRequest\x12\x15\n" +
-	"\x03for\x18\x03 \x01(\tB\x03\xe0A\x01R\x03for:\x8d\x01\x92A\x89\x012\x86\x01{\"subscribe_request\": { \"group_address\": \"1/2/3\", \"physical_address\": \"0.0.0\", \"event\": \"EVENT_WRITE\", \"data\": \"AQo=\" }, \"for\": \"10s\"}\"\\\n" +
-	"\x16SubscribeUnaryResponse\x12B\n" +
-	"\bmessages\x18\x01 \x03(\v2&.knx.groupaddress.v1.SubscribeResponseR\bmessages*S\n" +
+type ScanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// first group address of the range to scan, required
+	// valid format: 1/2/3
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// last group address of the range to scan (inclusive), optional (defaults to from)
+	// valid format: 1/2/3
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// pause between read requests as duration string, optional (defaults to 100ms)
+	Interval string `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// wait for late responses after the last read request as duration string, optional (defaults to knx.timeout)
+	Timeout string `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// datapoint type to check response data against, optional
+	// valid format: 1.001
+	Dpt           string `protobuf:"bytes,5,opt,name=dpt,proto3" json:"dpt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanRequest) ProtoMessage() {}
+
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScanRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetDpt() string {
+	if x != nil {
+		return x.Dpt
+	}
+	return ""
+}
+
+type ScanResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// number of group addresses which were read
+	Scanned uint32 `protobuf:"varint,1,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	// results of group addresses which answered
+	Results       []*ScanResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResponse) ProtoMessage() {}
+
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResponse.ProtoReflect.Descriptor instead.
+func (*ScanResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScanResponse) GetScanned() uint32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *ScanResponse) GetResults() []*ScanResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ScanResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_address which answered
+	GroupAddress string `protobuf:"bytes,1,opt,name=group_address,json=groupAddress,proto3" json:"group_address,omitempty"`
+	// physical_address of the responding device
+	PhysicalAddress string `protobuf:"bytes,2,opt,name=physical_address,json=physicalAddress,proto3" json:"physical_address,omitempty"`
+	// data of the response
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// dpt_valid reports whether data could be decoded using the requested dpt
+	DptValid bool `protobuf:"varint,4,opt,name=dpt_valid,json=dptValid,proto3" json:"dpt_valid,omitempty"`
+	// value is the decoded data if dpt was requested and valid
+	Value         string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResult) Reset() {
+	*x = ScanResult{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResult) ProtoMessage() {}
+
+func (x *ScanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResult.ProtoReflect.Descriptor instead.
+func (*ScanResult) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScanResult) GetGroupAddress() string {
+	if x != nil {
+		return x.GroupAddress
+	}
+	return ""
+}
+
+func (x *ScanResult) GetPhysicalAddress() string {
+	if x != nil {
+		return x.PhysicalAddress
+	}
+	return ""
+}
+
+func (x *ScanResult) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ScanResult) GetDptValid() bool {
+	if x != nil {
+		return x.DptValid
+	}
+	return false
+}
+
+func (x *ScanResult) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ListScheduledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRequest) Reset() {
+	*x = ListScheduledRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRequest) ProtoMessage() {}
+
+func (x *ListScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{10}
+}
+
+type ListScheduledResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// scheduled publishes ordered by time
+	Scheduled     []*ScheduledPublish `protobuf:"bytes,1,rep,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledResponse) Reset() {
+	*x = ListScheduledResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledResponse) ProtoMessage() {}
+
+func (x *ListScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListScheduledResponse) GetScheduled() []*ScheduledPublish {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type ScheduledPublish struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the scheduled publish
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// at is the RFC3339 time of the publish
+	At string `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	// publish_request which will be published
+	PublishRequest *PublishRequest `protobuf:"bytes,3,opt,name=publish_request,json=publishRequest,proto3" json:"publish_request,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScheduledPublish) Reset() {
+	*x = ScheduledPublish{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPublish) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPublish) ProtoMessage() {}
+
+func (x *ScheduledPublish) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPublish.ProtoReflect.Descriptor instead.
+func (*ScheduledPublish) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScheduledPublish) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledPublish) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *ScheduledPublish) GetPublishRequest() *PublishRequest {
+	if x != nil {
+		return x.PublishRequest
+	}
+	return nil
+}
+
+type CancelScheduledRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the scheduled publish to cancel, required
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledRequest) Reset() {
+	*x = CancelScheduledRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelScheduledRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledResponse) Reset() {
+	*x = CancelScheduledResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledResponse) ProtoMessage() {}
+
+func (x *CancelScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{14}
+}
+
+type GroupAddressValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_address of the value
+	// valid format: 1/2/3
+	GroupAddress string `protobuf:"bytes,1,opt,name=group_address,json=groupAddress,proto3" json:"group_address,omitempty"`
+	// data of the value
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupAddressValue) Reset() {
+	*x = GroupAddressValue{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAddressValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAddressValue) ProtoMessage() {}
+
+func (x *GroupAddressValue) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAddressValue.ProtoReflect.Descriptor instead.
+func (*GroupAddressValue) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GroupAddressValue) GetGroupAddress() string {
+	if x != nil {
+		return x.GroupAddress
+	}
+	return ""
+}
+
+func (x *GroupAddressValue) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type TransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// writes to apply, required
+	Writes []*GroupAddressValue `protobuf:"bytes,1,rep,name=writes,proto3" json:"writes,omitempty"`
+	// revert automatically after this duration string, optional (defaults to never)
+	Expires       string `protobuf:"bytes,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TransactionRequest) GetWrites() []*GroupAddressValue {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+func (x *TransactionRequest) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+type TransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the transaction
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// previous values which will be restored on revert
+	Previous      []*GroupAddressValue `protobuf:"bytes,2,rep,name=previous,proto3" json:"previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionResponse) Reset() {
+	*x = TransactionResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResponse) ProtoMessage() {}
+
+func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TransactionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetPrevious() []*GroupAddressValue {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+type CommitTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the transaction to commit, required
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitTransactionRequest) Reset() {
+	*x = CommitTransactionRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitTransactionRequest) ProtoMessage() {}
+
+func (x *CommitTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CommitTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CommitTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CommitTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitTransactionResponse) Reset() {
+	*x = CommitTransactionResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitTransactionResponse) ProtoMessage() {}
+
+func (x *CommitTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitTransactionResponse.ProtoReflect.Descriptor instead.
+func (*CommitTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{19}
+}
+
+type RevertTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the transaction to revert, required
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertTransactionRequest) Reset() {
+	*x = RevertTransactionRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTransactionRequest) ProtoMessage() {}
+
+func (x *RevertTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTransactionRequest.ProtoReflect.Descriptor instead.
+func (*RevertTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RevertTransactionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevertTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertTransactionResponse) Reset() {
+	*x = RevertTransactionResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTransactionResponse) ProtoMessage() {}
+
+func (x *RevertTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTransactionResponse.ProtoReflect.Descriptor instead.
+func (*RevertTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{21}
+}
+
+type AggregateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_address to aggregate, required
+	// valid format: 1/2/3
+	GroupAddress string `protobuf:"bytes,1,opt,name=group_address,json=groupAddress,proto3" json:"group_address,omitempty"`
+	// datapoint type to decode values with, required
+	// valid format: 9.001
+	Dpt string `protobuf:"bytes,2,opt,name=dpt,proto3" json:"dpt,omitempty"`
+	// start of the time range as RFC3339 time, optional (defaults to rpc.history.retention ago)
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// end of the time range as RFC3339 time, optional (defaults to now)
+	To string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// bucket size as duration string, optional (defaults to a single bucket)
+	Bucket        string `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateRequest) Reset() {
+	*x = AggregateRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRequest) ProtoMessage() {}
+
+func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRequest.ProtoReflect.Descriptor instead.
+func (*AggregateRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AggregateRequest) GetGroupAddress() string {
+	if x != nil {
+		return x.GroupAddress
+	}
+	return ""
+}
+
+func (x *AggregateRequest) GetDpt() string {
+	if x != nil {
+		return x.Dpt
+	}
+	return ""
+}
+
+func (x *AggregateRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AggregateRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *AggregateRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type AggregateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// buckets ordered by time, buckets without values are omitted
+	Buckets       []*AggregateBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateResponse) Reset() {
+	*x = AggregateResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateResponse) ProtoMessage() {}
+
+func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AggregateResponse) GetBuckets() []*AggregateBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type AggregateBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start of the bucket as RFC3339 time
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end of the bucket as RFC3339 time (exclusive)
+	End string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// count of values within the bucket
+	Count uint32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Min   float64 `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	Avg   float64 `protobuf:"fixed64,6,opt,name=avg,proto3" json:"avg,omitempty"`
+	// last value within the bucket
+	Last          float64 `protobuf:"fixed64,7,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateBucket) Reset() {
+	*x = AggregateBucket{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateBucket) ProtoMessage() {}
+
+func (x *AggregateBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateBucket.ProtoReflect.Descriptor instead.
+func (*AggregateBucket) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AggregateBucket) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *AggregateBucket) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *AggregateBucket) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AggregateBucket) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *AggregateBucket) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *AggregateBucket) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *AggregateBucket) GetLast() float64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+type ListGroupAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupAddressesRequest) Reset() {
+	*x = ListGroupAddressesRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupAddressesRequest) ProtoMessage() {}
+
+func (x *ListGroupAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{25}
+}
+
+type ListGroupAddressesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_addresses ordered by group address
+	GroupAddresses []*GroupAddressInfo `protobuf:"bytes,1,rep,name=group_addresses,json=groupAddresses,proto3" json:"group_addresses,omitempty"`
+	// dpts lists all supported datapoint types
+	Dpts          []string `protobuf:"bytes,2,rep,name=dpts,proto3" json:"dpts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupAddressesResponse) Reset() {
+	*x = ListGroupAddressesResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupAddressesResponse) ProtoMessage() {}
+
+func (x *ListGroupAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListGroupAddressesResponse) GetGroupAddresses() []*GroupAddressInfo {
+	if x != nil {
+		return x.GroupAddresses
+	}
+	return nil
+}
+
+func (x *ListGroupAddressesResponse) GetDpts() []string {
+	if x != nil {
+		return x.Dpts
+	}
+	return nil
+}
+
+type GroupAddressInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// group_address in format 1/2/3
+	GroupAddress string `protobuf:"bytes,1,opt,name=group_address,json=groupAddress,proto3" json:"group_address,omitempty"`
+	// name as configured, if any
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// dpt as configured, if any
+	Dpt string `protobuf:"bytes,3,opt,name=dpt,proto3" json:"dpt,omitempty"`
+	// last event carrying a value, if any was seen
+	Last *SubscribeResponse `protobuf:"bytes,4,opt,name=last,proto3" json:"last,omitempty"`
+	// last_time is the RFC3339 time of last
+	LastTime      string `protobuf:"bytes,5,opt,name=last_time,json=lastTime,proto3" json:"last_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupAddressInfo) Reset() {
+	*x = GroupAddressInfo{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAddressInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAddressInfo) ProtoMessage() {}
+
+func (x *GroupAddressInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAddressInfo.ProtoReflect.Descriptor instead.
+func (*GroupAddressInfo) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GroupAddressInfo) GetGroupAddress() string {
+	if x != nil {
+		return x.GroupAddress
+	}
+	return ""
+}
+
+func (x *GroupAddressInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GroupAddressInfo) GetDpt() string {
+	if x != nil {
+		return x.Dpt
+	}
+	return ""
+}
+
+func (x *GroupAddressInfo) GetLast() *SubscribeResponse {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+func (x *GroupAddressInfo) GetLastTime() string {
+	if x != nil {
+		return x.LastTime
+	}
+	return ""
+}
+
+type ListSubscribersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribersRequest) Reset() {
+	*x = ListSubscribersRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribersRequest) ProtoMessage() {}
+
+func (x *ListSubscribersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscribersRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscribersRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{28}
+}
+
+type ListSubscribersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// subscribers ordered by their start time
+	Subscribers   []*SubscriberInfo `protobuf:"bytes,1,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscribersResponse) Reset() {
+	*x = ListSubscribersResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscribersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscribersResponse) ProtoMessage() {}
+
+func (x *ListSubscribersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscribersResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscribersResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListSubscribersResponse) GetSubscribers() []*SubscriberInfo {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+type SubscriberInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the stream
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// identity which opened the stream
+	Identity string `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// peer address of the stream
+	Peer string `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	// started_at is the RFC3339 time the stream was opened
+	StartedAt string `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// group_addresses subscribed to, empty for all
+	GroupAddresses []string `protobuf:"bytes,5,rep,name=group_addresses,json=groupAddresses,proto3" json:"group_addresses,omitempty"`
+	// event filter of the stream
+	Event Event `protobuf:"varint,6,opt,name=event,proto3,enum=knx.groupaddress.v1.Event" json:"event,omitempty"`
+	// delivered counts messages sent to the stream
+	Delivered uint64 `protobuf:"varint,7,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	// filtered counts messages not sent due to the event or tenant filter
+	Filtered uint64 `protobuf:"varint,8,opt,name=filtered,proto3" json:"filtered,omitempty"`
+	// dropped counts messages which failed to be sent
+	Dropped       uint64 `protobuf:"varint,9,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriberInfo) Reset() {
+	*x = SubscriberInfo{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriberInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberInfo) ProtoMessage() {}
+
+func (x *SubscriberInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberInfo.ProtoReflect.Descriptor instead.
+func (*SubscriberInfo) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SubscriberInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *SubscriberInfo) GetGroupAddresses() []string {
+	if x != nil {
+		return x.GroupAddresses
+	}
+	return nil
+}
+
+func (x *SubscriberInfo) GetEvent() Event {
+	if x != nil {
+		return x.Event
+	}
+	return Event_EVENT_UNSPECIFIED
+}
+
+func (x *SubscriberInfo) GetDelivered() uint64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetFiltered() uint64 {
+	if x != nil {
+		return x.Filtered
+	}
+	return 0
+}
+
+func (x *SubscriberInfo) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type SubscribeAckedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// consumer names the durable subscription of the identity, required in the first message
+	Consumer string `protobuf:"bytes,1,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	// subscribe_request of a new consumer, only read from the first message
+	// an existing consumer keeps its subscription
+	SubscribeRequest *SubscribeRequest `protobuf:"bytes,2,opt,name=subscribe_request,json=subscribeRequest,proto3" json:"subscribe_request,omitempty"`
+	// acks are the delivery_ids of processed messages, optional
+	Acks          []uint64 `protobuf:"varint,3,rep,packed,name=acks,proto3" json:"acks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAckedRequest) Reset() {
+	*x = SubscribeAckedRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAckedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAckedRequest) ProtoMessage() {}
+
+func (x *SubscribeAckedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeAckedRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeAckedRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SubscribeAckedRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *SubscribeAckedRequest) GetSubscribeRequest() *SubscribeRequest {
+	if x != nil {
+		return x.SubscribeRequest
+	}
+	return nil
+}
+
+func (x *SubscribeAckedRequest) GetAcks() []uint64 {
+	if x != nil {
+		return x.Acks
+	}
+	return nil
+}
+
+type SubscribeAckedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// delivery_id to acknowledge message with, increasing per consumer
+	DeliveryId uint64 `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	// message received from the bus
+	Message *SubscribeResponse `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// redelivered is set if message was sent before without being acknowledged
+	Redelivered   bool `protobuf:"varint,3,opt,name=redelivered,proto3" json:"redelivered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAckedResponse) Reset() {
+	*x = SubscribeAckedResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAckedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAckedResponse) ProtoMessage() {}
+
+func (x *SubscribeAckedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeAckedResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeAckedResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubscribeAckedResponse) GetDeliveryId() uint64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+func (x *SubscribeAckedResponse) GetMessage() *SubscribeResponse {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SubscribeAckedResponse) GetRedelivered() bool {
+	if x != nil {
+		return x.Redelivered
+	}
+	return false
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{33}
+}
+
+type GetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// gateway is the host:port of the connected KNX gateway
+	Gateway string `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	// individual_address assigned to the tunnel by the gateway, valid format: 1.1.5
+	// empty until learned from the confirmation of the first telegram sent
+	IndividualAddress string `protobuf:"bytes,2,opt,name=individual_address,json=individualAddress,proto3" json:"individual_address,omitempty"`
+	// connected_at is the RFC3339 time the tunnel was connected
+	ConnectedAt   string `protobuf:"bytes,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetStatusResponse) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetIndividualAddress() string {
+	if x != nil {
+		return x.IndividualAddress
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetConnectedAt() string {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return ""
+}
+
+var File_knx_groupaddress_v1_groupaddressservice_proto protoreflect.FileDescriptor
+
+const file_knx_groupaddress_v1_groupaddressservice_proto_rawDesc = "" +
+	"\n" +
+	"-knx/groupaddress/v1/groupaddressservice.proto\x12\x13knx.groupaddress.v1\x1a\x1bgoogle/api/visibility.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a google/protobuf/field_mask.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xb2\x03\n" +
+	"\x0ePublishRequest\x12(\n" +
+	"\rgroup_address\x18\x01 \x01(\tB\x03\xe0A\x02R\fgroupAddress\x12.\n" +
+	"\x10physical_address\x18\x02 \x01(\tB\x03\xe0A\x01R\x0fphysicalAddress\x125\n" +
+	"\x05event\x18\x03 \x01(\x0e2\x1a.knx.groupaddress.v1.EventB\x03\xe0A\x01R\x05event\x12\x17\n" +
+	"\x04data\x18\x04 \x01(\fB\x03\xe0A\x01R\x04data\x12,\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tB\x03\xe0A\x01R\x0eidempotencyKey\x12\x19\n" +
+	"\x05delay\x18\x06 \x01(\tB\x03\xe0A\x01R\x05delay\x12\x13\n" +
+	"\x02at\x18\a \x01(\tB\x03\xe0A\x01R\x02at\x12\x19\n" +
+	"\x05value\x18\b \x01(\tB\x03\xe0A\x01R\x05value\x12\x15\n" +
+	"\x03dpt\x18\t \x01(\tB\x03\xe0A\x01R\x03dpt:f\x92Ac2a{ \"group_address\": \"1/2/3\", \"physical_address\": \"0.0.0\", \"event\": \"EVENT_WRITE\", \"data\": \"AQo=\" }\"4\n" +
+	"\x0fPublishResponse\x12!\n" +
+	"\fscheduled_id\x18\x01 \x01(\tR\vscheduledId\"\xeb\x02\n" +
+	"\x10SubscribeRequest\x12,\n" +
+	"\x0fgroup_addresses\x18\x01 \x03(\tB\x03\xe0A\x01R\x0egroupAddresses\x125\n" +
+	"\x05event\x18\x02 \x01(\x0e2\x1a.knx.groupaddress.v1.EventB\x03\xe0A\x01R\x05event\x127\n" +
+	"\x06fields\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\x06fields\x12$\n" +
+	"\vresume_from\x18\x04 \x01(\tB\x03\xe0A\x01R\n" +
+	"resumeFrom\x12E\n" +
+	"\vreplay_last\x18\x05 \x01(\v2\x1f.knx.groupaddress.v1.ReplayLastB\x03\xe0A\x01R\n" +
+	"replayLast:L\x92AI2G{ \"group_addresses\": [\"1/2/3\", \"4/5/6\"], \"event\": \"EVENT_UNSPECIFIED\" }\"D\n" +
+	"\n" +
+	"ReplayLast\x12\x19\n" +
+	"\x05count\x18\x01 \x01(\rB\x03\xe0A\x01R\x05count\x12\x1b\n" +
+	"\x06within\x18\x02 \x01(\tB\x03\xe0A\x01R\x06within\"\xe2\x01\n" +
+	"\x11SubscribeResponse\x12#\n" +
+	"\rgroup_address\x18\x01 \x01(\tR\fgroupAddress\x12)\n" +
+	"\x10physical_address\x18\x02 \x01(\tR\x0fphysicalAddress\x120\n" +
+	"\x05event\x18\x03 \x01(\x0e2\x1a.knx.groupaddress.v1.EventR\x05event\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12!\n" +
+	"\fresume_token\x18\x06 \x01(\tR\vresumeToken\"\xd3\x02\n" +
+	"\x15SubscribeUnaryRequest\x12W\n" +
+	"\x11subscribe_request\x18\x01 \x01(\v2%.knx.groupaddress.v1.SubscribeRequestB\x03\xe0A\x01R\x10subscribeRequest\x12\x15\n" +
+	"\x03for\x18\x03 \x01(\tB\x03\xe0A\x01R\x03for\x12&\n" +
+	"\fmax_messages\x18\x04 \x01(\rB\x03\xe0A\x01R\vmaxMessages:\xa1\x01\x92A\x9d\x012\x9a\x01{\"subscribe_request\": { \"group_address\": \"1/2/3\", \"physical_address\": \"0.0.0\", \"event\": \"EVENT_WRITE\", \"data\": \"AQo=\" }, \"for\": \"10s\", \"max_messages\": 10}\"\x9f\x01\n" +
+	"\x16SubscribeUnaryResponse\x12B\n" +
+	"\bmessages\x18\x01 \x03(\v2&.knx.groupaddress.v1.SubscribeResponseR\bmessages\x12A\n" +
+	"\x06reason\x18\x02 \x01(\x0e2).knx.groupaddress.v1.SubscribeUnaryReasonR\x06reason\"\xe2\x01\n" +
+	"\vScanRequest\x12\x17\n" +
+	"\x04from\x18\x01 \x01(\tB\x03\xe0A\x02R\x04from\x12\x13\n" +
+	"\x02to\x18\x02 \x01(\tB\x03\xe0A\x01R\x02to\x12\x1f\n" +
+	"\binterval\x18\x03 \x01(\tB\x03\xe0A\x01R\binterval\x12\x1d\n" +
+	"\atimeout\x18\x04 \x01(\tB\x03\xe0A\x01R\atimeout\x12\x15\n" +
+	"\x03dpt\x18\x05 \x01(\tB\x03\xe0A\x01R\x03dpt:N\x92AK2I{ \"from\": \"1/1/0\", \"to\": \"1/1/255\", \"interval\": \"100ms\", \"dpt\": \"1.001\" }\"c\n" +
+	"\fScanResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\rR\ascanned\x129\n" +
+	"\aresults\x18\x02 \x03(\v2\x1f.knx.groupaddress.v1.ScanResultR\aresults\"\xa3\x01\n" +
+	"\n" +
+	"ScanResult\x12#\n" +
+	"\rgroup_address\x18\x01 \x01(\tR\fgroupAddress\x12)\n" +
+	"\x10physical_address\x18\x02 \x01(\tR\x0fphysicalAddress\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1b\n" +
+	"\tdpt_valid\x18\x04 \x01(\bR\bdptValid\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\"\x16\n" +
+	"\x14ListScheduledRequest\"\\\n" +
+	"\x15ListScheduledResponse\x12C\n" +
+	"\tscheduled\x18\x01 \x03(\v2%.knx.groupaddress.v1.ScheduledPublishR\tscheduled\"\x80\x01\n" +
+	"\x10ScheduledPublish\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x0e\n" +
+	"\x02at\x18\x02 \x01(\tR\x02at\x12L\n" +
+	"\x0fpublish_request\x18\x03 \x01(\v2#.knx.groupaddress.v1.PublishRequestR\x0epublishRequest\"P\n" +
+	"\x16CancelScheduledRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id:!\x92A\x1e2\x1c{ \"id\": \"4f2a9c1d8e7b6a50\" }\"\x19\n" +
+	"\x17CancelScheduledResponse\"V\n" +
+	"\x11GroupAddressValue\x12(\n" +
+	"\rgroup_address\x18\x01 \x01(\tB\x03\xe0A\x02R\fgroupAddress\x12\x17\n" +
+	"\x04data\x18\x02 \x01(\fB\x03\xe0A\x02R\x04data\"\xcc\x01\n" +
+	"\x12TransactionRequest\x12C\n" +
+	"\x06writes\x18\x01 \x03(\v2&.knx.groupaddress.v1.GroupAddressValueB\x03\xe0A\x02R\x06writes\x12\x1d\n" +
+	"\aexpires\x18\x02 \x01(\tB\x03\xe0A\x01R\aexpires:R\x92AO2M{ \"writes\": [{ \"group_address\": \"1/2/3\", \"data\": \"DBo=\" }], \"expires\": \"1h\" }\"i\n" +
+	"\x13TransactionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12B\n" +
+	"\bprevious\x18\x02 \x03(\v2&.knx.groupaddress.v1.GroupAddressValueR\bprevious\"R\n" +
+	"\x18CommitTransactionRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id:!\x92A\x1e2\x1c{ \"id\": \"4f2a9c1d8e7b6a50\" }\"\x1b\n" +
+	"\x19CommitTransactionResponse\"R\n" +
+	"\x18RevertTransactionRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id:!\x92A\x1e2\x1c{ \"id\": \"4f2a9c1d8e7b6a50\" }\"\x1b\n" +
+	"\x19RevertTransactionResponse\"\xe1\x01\n" +
+	"\x10AggregateRequest\x12(\n" +
+	"\rgroup_address\x18\x01 \x01(\tB\x03\xe0A\x02R\fgroupAddress\x12\x15\n" +
+	"\x03dpt\x18\x02 \x01(\tB\x03\xe0A\x02R\x03dpt\x12\x17\n" +
+	"\x04from\x18\x03 \x01(\tB\x03\xe0A\x01R\x04from\x12\x13\n" +
+	"\x02to\x18\x04 \x01(\tB\x03\xe0A\x01R\x02to\x12\x1b\n" +
+	"\x06bucket\x18\x05 \x01(\tB\x03\xe0A\x01R\x06bucket:A\x92A>2<{ \"group_address\": \"1/2/3\", \"dpt\": \"9.001\", \"bucket\": \"1h\" }\"S\n" +
+	"\x11AggregateResponse\x12>\n" +
+	"\abuckets\x18\x01 \x03(\v2$.knx.groupaddress.v1.AggregateBucketR\abuckets\"\x99\x01\n" +
+	"\x0fAggregateBucket\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\rR\x05count\x12\x10\n" +
+	"\x03min\x18\x04 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\x01R\x03max\x12\x10\n" +
+	"\x03avg\x18\x06 \x01(\x01R\x03avg\x12\x12\n" +
+	"\x04last\x18\a \x01(\x01R\x04last\"\x1b\n" +
+	"\x19ListGroupAddressesRequest\"\x80\x01\n" +
+	"\x1aListGroupAddressesResponse\x12N\n" +
+	"\x0fgroup_addresses\x18\x01 \x03(\v2%.knx.groupaddress.v1.GroupAddressInfoR\x0egroupAddresses\x12\x12\n" +
+	"\x04dpts\x18\x02 \x03(\tR\x04dpts\"\xb6\x01\n" +
+	"\x10GroupAddressInfo\x12#\n" +
+	"\rgroup_address\x18\x01 \x01(\tR\fgroupAddress\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03dpt\x18\x03 \x01(\tR\x03dpt\x12:\n" +
+	"\x04last\x18\x04 \x01(\v2&.knx.groupaddress.v1.SubscribeResponseR\x04last\x12\x1b\n" +
+	"\tlast_time\x18\x05 \x01(\tR\blastTime\"\x18\n" +
+	"\x16ListSubscribersRequest\"`\n" +
+	"\x17ListSubscribersResponse\x12E\n" +
+	"\vsubscribers\x18\x01 \x03(\v2#.knx.groupaddress.v1.SubscriberInfoR\vsubscribers\"\x9e\x02\n" +
+	"\x0eSubscriberInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\tR\tstartedAt\x12'\n" +
+	"\x0fgroup_addresses\x18\x05 \x03(\tR\x0egroupAddresses\x120\n" +
+	"\x05event\x18\x06 \x01(\x0e2\x1a.knx.groupaddress.v1.EventR\x05event\x12\x1c\n" +
+	"\tdelivered\x18\a \x01(\x04R\tdelivered\x12\x1a\n" +
+	"\bfiltered\x18\b \x01(\x04R\bfiltered\x12\x18\n" +
+	"\adropped\x18\t \x01(\x04R\adropped\"\x80\x02\n" +
+	"\x15SubscribeAckedRequest\x12\x1f\n" +
+	"\bconsumer\x18\x01 \x01(\tB\x03\xe0A\x01R\bconsumer\x12W\n" +
+	"\x11subscribe_request\x18\x02 \x01(\v2%.knx.groupaddress.v1.SubscribeRequestB\x03\xe0A\x01R\x10subscribeRequest\x12\x17\n" +
+	"\x04acks\x18\x03 \x03(\x04B\x03\xe0A\x01R\x04acks:T\x92AQ2O{ \"consumer\": \"energy\", \"subscribe_request\": { \"group_addresses\": [\"1/2/3\"] } }\"\x9d\x01\n" +
+	"\x16SubscribeAckedResponse\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x04R\n" +
+	"deliveryId\x12@\n" +
+	"\amessage\x18\x02 \x01(\v2&.knx.groupaddress.v1.SubscribeResponseR\amessage\x12 \n" +
+	"\vredelivered\x18\x03 \x01(\bR\vredelivered\"\x12\n" +
+	"\x10GetStatusRequest\"\x7f\n" +
+	"\x11GetStatusResponse\x12\x18\n" +
+	"\agateway\x18\x01 \x01(\tR\agateway\x12-\n" +
+	"\x12individual_address\x18\x02 \x01(\tR\x11individualAddress\x12!\n" +
+	"\fconnected_at\x18\x03 \x01(\tR\vconnectedAt*S\n" +
 	"\x05Event\x12\x15\n" +
 	"\x11EVENT_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
 	"EVENT_READ\x10\x01\x12\x12\n" +
 	"\x0eEVENT_RESPONSE\x10\x02\x12\x0f\n" +
-	"\vEVENT_WRITE\x10\x032\xd8\x02\n" +
+	"\vEVENT_WRITE\x10\x03*\x8b\x01\n" +
+	"\x14SubscribeUnaryReason\x12&\n" +
+	"\"SUBSCRIBE_UNARY_REASON_UNSPECIFIED\x10\x00\x12'\n" +
+	"#SUBSCRIBE_UNARY_REASON_MAX_MESSAGES\x10\x01\x12\"\n" +
+	"\x1eSUBSCRIBE_UNARY_REASON_TIMEOUT\x10\x022\xee\f\n" +
 	"\x13GroupAddressService\x12V\n" +
 	"\aPublish\x12#.knx.groupaddress.v1.PublishRequest\x1a$.knx.groupaddress.v1.PublishResponse\"\x00\x12^\n" +
 	"\tSubscribe\x12%.knx.groupaddress.v1.SubscribeRequest\x1a&.knx.groupaddress.v1.SubscribeResponse\"\x000\x01\x12w\n" +
-	"\x0eSubscribeUnary\x12*.knx.groupaddress.v1.SubscribeUnaryRequest\x1a+.knx.groupaddress.v1.SubscribeUnaryResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x1a\x10\xfa\xd2\xe4\x93\x02\n" +
+	"\x0eSubscribeUnary\x12*.knx.groupaddress.v1.SubscribeUnaryRequest\x1a+.knx.groupaddress.v1.SubscribeUnaryResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12Y\n" +
+	"\x04Scan\x12 .knx.groupaddress.v1.ScanRequest\x1a!.knx.groupaddress.v1.ScanResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12t\n" +
+	"\rListScheduled\x12).knx.groupaddress.v1.ListScheduledRequest\x1a*.knx.groupaddress.v1.ListScheduledResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12z\n" +
+	"\x0fCancelScheduled\x12+.knx.groupaddress.v1.CancelScheduledRequest\x1a,.knx.groupaddress.v1.CancelScheduledResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12n\n" +
+	"\vTransaction\x12'.knx.groupaddress.v1.TransactionRequest\x1a(.knx.groupaddress.v1.TransactionResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12\x80\x01\n" +
+	"\x11CommitTransaction\x12-.knx.groupaddress.v1.CommitTransactionRequest\x1a..knx.groupaddress.v1.CommitTransactionResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12\x80\x01\n" +
+	"\x11RevertTransaction\x12-.knx.groupaddress.v1.RevertTransactionRequest\x1a..knx.groupaddress.v1.RevertTransactionResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12h\n" +
+	"\tAggregate\x12%.knx.groupaddress.v1.AggregateRequest\x1a&.knx.groupaddress.v1.AggregateResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12\x83\x01\n" +
+	"\x12ListGroupAddresses\x12..knx.groupaddress.v1.ListGroupAddressesRequest\x1a/.knx.groupaddress.v1.ListGroupAddressesResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12z\n" +
+	"\x0fListSubscribers\x12+.knx.groupaddress.v1.ListSubscribersRequest\x1a,.knx.groupaddress.v1.ListSubscribersResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x12{\n" +
+	"\x0eSubscribeAcked\x12*.knx.groupaddress.v1.SubscribeAckedRequest\x1a+.knx.groupaddress.v1.SubscribeAckedResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA(\x010\x01\x12h\n" +
+	"\tGetStatus\x12%.knx.groupaddress.v1.GetStatusRequest\x1a&.knx.groupaddress.v1.GetStatusResponse\"\f\xfa\xd2\xe4\x93\x02\x06\x12\x04BETA\x1a\x10\xfa\xd2\xe4\x93\x02\n" +
 	"\x12\bRELEASEDB\x8d\x02\x92A\xdb\x01\x12z\n" +
 	"\x17KNX GroupAddressService\"L\n" +
 	"\x12Christoph Hoopmann\x12!https://github.com/choopm/knxrpc/\x1a\x13choopm@0pointer.org*\f\n" +
@@ -465,34 +2418,102 @@ func file_knx_groupaddress_v1_groupaddressservice_proto_rawDescGZIP() []byte {
 	return file_knx_groupaddress_v1_groupaddressservice_proto_rawDescData
 }
 
-var file_knx_groupaddress_v1_groupaddressservice_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_knx_groupaddress_v1_groupaddressservice_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_knx_groupaddress_v1_groupaddressservice_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_knx_groupaddress_v1_groupaddressservice_proto_goTypes = []any{
-	(Event)(0),                     // 0: knx.groupaddress.v1.Event
-	(*PublishRequest)(nil),         // 1: knx.groupaddress.v1.PublishRequest
-	(*PublishResponse)(nil),        // 2: knx.groupaddress.v1.PublishResponse
-	(*SubscribeRequest)(nil),       // 3: knx.groupaddress.v1.SubscribeRequest
-	(*SubscribeResponse)(nil),      // 4: knx.groupaddress.v1.SubscribeResponse
-	(*SubscribeUnaryRequest)(nil),  // 5: knx.groupaddress.v1.SubscribeUnaryRequest
-	(*SubscribeUnaryResponse)(nil), // 6: knx.groupaddress.v1.SubscribeUnaryResponse
+	(Event)(0),                         // 0: knx.groupaddress.v1.Event
+	(SubscribeUnaryReason)(0),          // 1: knx.groupaddress.v1.SubscribeUnaryReason
+	(*PublishRequest)(nil),             // 2: knx.groupaddress.v1.PublishRequest
+	(*PublishResponse)(nil),            // 3: knx.groupaddress.v1.PublishResponse
+	(*SubscribeRequest)(nil),           // 4: knx.groupaddress.v1.SubscribeRequest
+	(*ReplayLast)(nil),                 // 5: knx.groupaddress.v1.ReplayLast
+	(*SubscribeResponse)(nil),          // 6: knx.groupaddress.v1.SubscribeResponse
+	(*SubscribeUnaryRequest)(nil),      // 7: knx.groupaddress.v1.SubscribeUnaryRequest
+	(*SubscribeUnaryResponse)(nil),     // 8: knx.groupaddress.v1.SubscribeUnaryResponse
+	(*ScanRequest)(nil),                // 9: knx.groupaddress.v1.ScanRequest
+	(*ScanResponse)(nil),               // 10: knx.groupaddress.v1.ScanResponse
+	(*ScanResult)(nil),                 // 11: knx.groupaddress.v1.ScanResult
+	(*ListScheduledRequest)(nil),       // 12: knx.groupaddress.v1.ListScheduledRequest
+	(*ListScheduledResponse)(nil),      // 13: knx.groupaddress.v1.ListScheduledResponse
+	(*ScheduledPublish)(nil),           // 14: knx.groupaddress.v1.ScheduledPublish
+	(*CancelScheduledRequest)(nil),     // 15: knx.groupaddress.v1.CancelScheduledRequest
+	(*CancelScheduledResponse)(nil),    // 16: knx.groupaddress.v1.CancelScheduledResponse
+	(*GroupAddressValue)(nil),          // 17: knx.groupaddress.v1.GroupAddressValue
+	(*TransactionRequest)(nil),         // 18: knx.groupaddress.v1.TransactionRequest
+	(*TransactionResponse)(nil),        // 19: knx.groupaddress.v1.TransactionResponse
+	(*CommitTransactionRequest)(nil),   // 20: knx.groupaddress.v1.CommitTransactionRequest
+	(*CommitTransactionResponse)(nil),  // 21: knx.groupaddress.v1.CommitTransactionResponse
+	(*RevertTransactionRequest)(nil),   // 22: knx.groupaddress.v1.RevertTransactionRequest
+	(*RevertTransactionResponse)(nil),  // 23: knx.groupaddress.v1.RevertTransactionResponse
+	(*AggregateRequest)(nil),           // 24: knx.groupaddress.v1.AggregateRequest
+	(*AggregateResponse)(nil),          // 25: knx.groupaddress.v1.AggregateResponse
+	(*AggregateBucket)(nil),            // 26: knx.groupaddress.v1.AggregateBucket
+	(*ListGroupAddressesRequest)(nil),  // 27: knx.groupaddress.v1.ListGroupAddressesRequest
+	(*ListGroupAddressesResponse)(nil), // 28: knx.groupaddress.v1.ListGroupAddressesResponse
+	(*GroupAddressInfo)(nil),           // 29: knx.groupaddress.v1.GroupAddressInfo
+	(*ListSubscribersRequest)(nil),     // 30: knx.groupaddress.v1.ListSubscribersRequest
+	(*ListSubscribersResponse)(nil),    // 31: knx.groupaddress.v1.ListSubscribersResponse
+	(*SubscriberInfo)(nil),             // 32: knx.groupaddress.v1.SubscriberInfo
+	(*SubscribeAckedRequest)(nil),      // 33: knx.groupaddress.v1.SubscribeAckedRequest
+	(*SubscribeAckedResponse)(nil),     // 34: knx.groupaddress.v1.SubscribeAckedResponse
+	(*GetStatusRequest)(nil),           // 35: knx.groupaddress.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),          // 36: knx.groupaddress.v1.GetStatusResponse
+	(*fieldmaskpb.FieldMask)(nil),      // 37: google.protobuf.FieldMask
 }
 var file_knx_groupaddress_v1_groupaddressservice_proto_depIdxs = []int32{
-	0, // 0: knx.groupaddress.v1.PublishRequest.event:type_name -> knx.groupaddress.v1.Event
-	0, // 1: knx.groupaddress.v1.SubscribeRequest.event:type_name -> knx.groupaddress.v1.Event
-	0, // 2: knx.groupaddress.v1.SubscribeResponse.event:type_name -> knx.groupaddress.v1.Event
-	3, // 3: knx.groupaddress.v1.SubscribeUnaryRequest.subscribe_request:type_name -> knx.groupaddress.v1.SubscribeRequest
-	4, // 4: knx.groupaddress.v1.SubscribeUnaryResponse.messages:type_name -> knx.groupaddress.v1.SubscribeResponse
-	1, // 5: knx.groupaddress.v1.GroupAddressService.Publish:input_type -> knx.groupaddress.v1.PublishRequest
-	3, // 6: knx.groupaddress.v1.GroupAddressService.Subscribe:input_type -> knx.groupaddress.v1.SubscribeRequest
-	5, // 7: knx.groupaddress.v1.GroupAddressService.SubscribeUnary:input_type -> knx.groupaddress.v1.SubscribeUnaryRequest
-	2, // 8: knx.groupaddress.v1.GroupAddressService.Publish:output_type -> knx.groupaddress.v1.PublishResponse
-	4, // 9: knx.groupaddress.v1.GroupAddressService.Subscribe:output_type -> knx.groupaddress.v1.SubscribeResponse
-	6, // 10: knx.groupaddress.v1.GroupAddressService.SubscribeUnary:output_type -> knx.groupaddress.v1.SubscribeUnaryResponse
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: knx.groupaddress.v1.PublishRequest.event:type_name -> knx.groupaddress.v1.Event
+	0,  // 1: knx.groupaddress.v1.SubscribeRequest.event:type_name -> knx.groupaddress.v1.Event
+	37, // 2: knx.groupaddress.v1.SubscribeRequest.fields:type_name -> google.protobuf.FieldMask
+	5,  // 3: knx.groupaddress.v1.SubscribeRequest.replay_last:type_name -> knx.groupaddress.v1.ReplayLast
+	0,  // 4: knx.groupaddress.v1.SubscribeResponse.event:type_name -> knx.groupaddress.v1.Event
+	4,  // 5: knx.groupaddress.v1.SubscribeUnaryRequest.subscribe_request:type_name -> knx.groupaddress.v1.SubscribeRequest
+	6,  // 6: knx.groupaddress.v1.SubscribeUnaryResponse.messages:type_name -> knx.groupaddress.v1.SubscribeResponse
+	1,  // 7: knx.groupaddress.v1.SubscribeUnaryResponse.reason:type_name -> knx.groupaddress.v1.SubscribeUnaryReason
+	11, // 8: knx.groupaddress.v1.ScanResponse.results:type_name -> knx.groupaddress.v1.ScanResult
+	14, // 9: knx.groupaddress.v1.ListScheduledResponse.scheduled:type_name -> knx.groupaddress.v1.ScheduledPublish
+	2,  // 10: knx.groupaddress.v1.ScheduledPublish.publish_request:type_name -> knx.groupaddress.v1.PublishRequest
+	17, // 11: knx.groupaddress.v1.TransactionRequest.writes:type_name -> knx.groupaddress.v1.GroupAddressValue
+	17, // 12: knx.groupaddress.v1.TransactionResponse.previous:type_name -> knx.groupaddress.v1.GroupAddressValue
+	26, // 13: knx.groupaddress.v1.AggregateResponse.buckets:type_name -> knx.groupaddress.v1.AggregateBucket
+	29, // 14: knx.groupaddress.v1.ListGroupAddressesResponse.group_addresses:type_name -> knx.groupaddress.v1.GroupAddressInfo
+	6,  // 15: knx.groupaddress.v1.GroupAddressInfo.last:type_name -> knx.groupaddress.v1.SubscribeResponse
+	32, // 16: knx.groupaddress.v1.ListSubscribersResponse.subscribers:type_name -> knx.groupaddress.v1.SubscriberInfo
+	0,  // 17: knx.groupaddress.v1.SubscriberInfo.event:type_name -> knx.groupaddress.v1.Event
+	4,  // 18: knx.groupaddress.v1.SubscribeAckedRequest.subscribe_request:type_name -> knx.groupaddress.v1.SubscribeRequest
+	6,  // 19: knx.groupaddress.v1.SubscribeAckedResponse.message:type_name -> knx.groupaddress.v1.SubscribeResponse
+	2,  // 20: knx.groupaddress.v1.GroupAddressService.Publish:input_type -> knx.groupaddress.v1.PublishRequest
+	4,  // 21: knx.groupaddress.v1.GroupAddressService.Subscribe:input_type -> knx.groupaddress.v1.SubscribeRequest
+	7,  // 22: knx.groupaddress.v1.GroupAddressService.SubscribeUnary:input_type -> knx.groupaddress.v1.SubscribeUnaryRequest
+	9,  // 23: knx.groupaddress.v1.GroupAddressService.Scan:input_type -> knx.groupaddress.v1.ScanRequest
+	12, // 24: knx.groupaddress.v1.GroupAddressService.ListScheduled:input_type -> knx.groupaddress.v1.ListScheduledRequest
+	15, // 25: knx.groupaddress.v1.GroupAddressService.CancelScheduled:input_type -> knx.groupaddress.v1.CancelScheduledRequest
+	18, // 26: knx.groupaddress.v1.GroupAddressService.Transaction:input_type -> knx.groupaddress.v1.TransactionRequest
+	20, // 27: knx.groupaddress.v1.GroupAddressService.CommitTransaction:input_type -> knx.groupaddress.v1.CommitTransactionRequest
+	22, // 28: knx.groupaddress.v1.GroupAddressService.RevertTransaction:input_type -> knx.groupaddress.v1.RevertTransactionRequest
+	24, // 29: knx.groupaddress.v1.GroupAddressService.Aggregate:input_type -> knx.groupaddress.v1.AggregateRequest
+	27, // 30: knx.groupaddress.v1.GroupAddressService.ListGroupAddresses:input_type -> knx.groupaddress.v1.ListGroupAddressesRequest
+	30, // 31: knx.groupaddress.v1.GroupAddressService.ListSubscribers:input_type -> knx.groupaddress.v1.ListSubscribersRequest
+	33, // 32: knx.groupaddress.v1.GroupAddressService.SubscribeAcked:input_type -> knx.groupaddress.v1.SubscribeAckedRequest
+	35, // 33: knx.groupaddress.v1.GroupAddressService.GetStatus:input_type -> knx.groupaddress.v1.GetStatusRequest
+	3,  // 34: knx.groupaddress.v1.GroupAddressService.Publish:output_type -> knx.groupaddress.v1.PublishResponse
+	6,  // 35: knx.groupaddress.v1.GroupAddressService.Subscribe:output_type -> knx.groupaddress.v1.SubscribeResponse
+	8,  // 36: knx.groupaddress.v1.GroupAddressService.SubscribeUnary:output_type -> knx.groupaddress.v1.SubscribeUnaryResponse
+	10, // 37: knx.groupaddress.v1.GroupAddressService.Scan:output_type -> knx.groupaddress.v1.ScanResponse
+	13, // 38: knx.groupaddress.v1.GroupAddressService.ListScheduled:output_type -> knx.groupaddress.v1.ListScheduledResponse
+	16, // 39: knx.groupaddress.v1.GroupAddressService.CancelScheduled:output_type -> knx.groupaddress.v1.CancelScheduledResponse
+	19, // 40: knx.groupaddress.v1.GroupAddressService.Transaction:output_type -> knx.groupaddress.v1.TransactionResponse
+	21, // 41: knx.groupaddress.v1.GroupAddressService.CommitTransaction:output_type -> knx.groupaddress.v1.CommitTransactionResponse
+	23, // 42: knx.groupaddress.v1.GroupAddressService.RevertTransaction:output_type -> knx.groupaddress.v1.RevertTransactionResponse
+	25, // 43: knx.groupaddress.v1.GroupAddressService.Aggregate:output_type -> knx.groupaddress.v1.AggregateResponse
+	28, // 44: knx.groupaddress.v1.GroupAddressService.ListGroupAddresses:output_type -> knx.groupaddress.v1.ListGroupAddressesResponse
+	31, // 45: knx.groupaddress.v1.GroupAddressService.ListSubscribers:output_type -> knx.groupaddress.v1.ListSubscribersResponse
+	34, // 46: knx.groupaddress.v1.GroupAddressService.SubscribeAcked:output_type -> knx.groupaddress.v1.SubscribeAckedResponse
+	36, // 47: knx.groupaddress.v1.GroupAddressService.GetStatus:output_type -> knx.groupaddress.v1.GetStatusResponse
+	34, // [34:48] is the sub-list for method output_type
+	20, // [20:34] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_knx_groupaddress_v1_groupaddressservice_proto_init() }
@@ -505,8 +2526,8 @@ func file_knx_groupaddress_v1_groupaddressservice_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_knx_groupaddress_v1_groupaddressservice_proto_rawDesc), len(file_knx_groupaddress_v1_groupaddressservice_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
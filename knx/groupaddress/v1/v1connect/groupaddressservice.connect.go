@@ -42,6 +42,39 @@ const (
 	// GroupAddressServiceSubscribeUnaryProcedure is the fully-qualified name of the
 	// GroupAddressService's SubscribeUnary RPC.
 	GroupAddressServiceSubscribeUnaryProcedure = "/knx.groupaddress.v1.GroupAddressService/SubscribeUnary"
+	// GroupAddressServiceScanProcedure is the fully-qualified name of the GroupAddressService's Scan
+	// RPC.
+	GroupAddressServiceScanProcedure = "/knx.groupaddress.v1.GroupAddressService/Scan"
+	// GroupAddressServiceListScheduledProcedure is the fully-qualified name of the
+	// GroupAddressService's ListScheduled RPC.
+	GroupAddressServiceListScheduledProcedure = "/knx.groupaddress.v1.GroupAddressService/ListScheduled"
+	// GroupAddressServiceCancelScheduledProcedure is the fully-qualified name of the
+	// GroupAddressService's CancelScheduled RPC.
+	GroupAddressServiceCancelScheduledProcedure = "/knx.groupaddress.v1.GroupAddressService/CancelScheduled"
+	// GroupAddressServiceTransactionProcedure is the fully-qualified name of the GroupAddressService's
+	// Transaction RPC.
+	GroupAddressServiceTransactionProcedure = "/knx.groupaddress.v1.GroupAddressService/Transaction"
+	// GroupAddressServiceCommitTransactionProcedure is the fully-qualified name of the
+	// GroupAddressService's CommitTransaction RPC.
+	GroupAddressServiceCommitTransactionProcedure = "/knx.groupaddress.v1.GroupAddressService/CommitTransaction"
+	// GroupAddressServiceRevertTransactionProcedure is the fully-qualified name of the
+	// GroupAddressService's RevertTransaction RPC.
+	GroupAddressServiceRevertTransactionProcedure = "/knx.groupaddress.v1.GroupAddressService/RevertTransaction"
+	// GroupAddressServiceAggregateProcedure is the fully-qualified name of the GroupAddressService's
+	// Aggregate RPC.
+	GroupAddressServiceAggregateProcedure = "/knx.groupaddress.v1.GroupAddressService/Aggregate"
+	// GroupAddressServiceListGroupAddressesProcedure is the fully-qualified name of the
+	// GroupAddressService's ListGroupAddresses RPC.
+	GroupAddressServiceListGroupAddressesProcedure = "/knx.groupaddress.v1.GroupAddressService/ListGroupAddresses"
+	// GroupAddressServiceListSubscribersProcedure is the fully-qualified name of the
+	// GroupAddressService's ListSubscribers RPC.
+	GroupAddressServiceListSubscribersProcedure = "/knx.groupaddress.v1.GroupAddressService/ListSubscribers"
+	// GroupAddressServiceSubscribeAckedProcedure is the fully-qualified name of the
+	// GroupAddressService's SubscribeAcked RPC.
+	GroupAddressServiceSubscribeAckedProcedure = "/knx.groupaddress.v1.GroupAddressService/SubscribeAcked"
+	// GroupAddressServiceGetStatusProcedure is the fully-qualified name of the GroupAddressService's
+	// GetStatus RPC.
+	GroupAddressServiceGetStatusProcedure = "/knx.groupaddress.v1.GroupAddressService/GetStatus"
 )
 
 // GroupAddressServiceClient is a client for the knx.groupaddress.v1.GroupAddressService service.
@@ -56,6 +89,38 @@ type GroupAddressServiceClient interface {
 	// Bus messages are delivered as an array of wrapped streamed responses.
 	// It is up to you to react on a message or ignore it.
 	SubscribeUnary(context.Context, *connect.Request[v1.SubscribeUnaryRequest]) (*connect.Response[v1.SubscribeUnaryResponse], error)
+	// Scan reads a range of group addresses with pacing and reports which
+	// of them answered. Useful for verifying new installations.
+	Scan(context.Context, *connect.Request[v1.ScanRequest]) (*connect.Response[v1.ScanResponse], error)
+	// ListScheduled lists publishes which are scheduled for a later time
+	ListScheduled(context.Context, *connect.Request[v1.ListScheduledRequest]) (*connect.Response[v1.ListScheduledResponse], error)
+	// CancelScheduled cancels a scheduled publish
+	CancelScheduled(context.Context, *connect.Request[v1.CancelScheduledRequest]) (*connect.Response[v1.CancelScheduledResponse], error)
+	// Transaction reads the current values of group addresses and writes new
+	// values. The previous values are restored using RevertTransaction or
+	// automatically once the transaction expires.
+	Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error)
+	// CommitTransaction keeps the written values and forgets the previous values
+	CommitTransaction(context.Context, *connect.Request[v1.CommitTransactionRequest]) (*connect.Response[v1.CommitTransactionResponse], error)
+	// RevertTransaction restores the previous values of a transaction
+	RevertTransaction(context.Context, *connect.Request[v1.RevertTransactionRequest]) (*connect.Response[v1.RevertTransactionResponse], error)
+	// Aggregate returns statistics of numeric values of a group address
+	// recorded by the history over time buckets.
+	Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error)
+	// ListGroupAddresses lists the configured group addresses merged with
+	// all group addresses seen on the bus including their last value.
+	ListGroupAddresses(context.Context, *connect.Request[v1.ListGroupAddressesRequest]) (*connect.Response[v1.ListGroupAddressesResponse], error)
+	// ListSubscribers lists the open Subscribe streams and their statistics.
+	// The statistics are also sent as trailers once a stream closes.
+	ListSubscribers(context.Context, *connect.Request[v1.ListSubscribersRequest]) (*connect.Response[v1.ListSubscribersResponse], error)
+	// SubscribeAcked is Subscribe with at-least-once delivery for a named consumer.
+	// Messages are retained until acknowledged and redelivered after reconnecting,
+	// the consumer keeps receiving while disconnected within rpc.ack.retention.
+	// A new stream of a connected consumer replaces its previous stream.
+	// Requires HTTP/2.
+	SubscribeAcked(context.Context) *connect.BidiStreamForClient[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse]
+	// GetStatus returns the state of the KNX tunnel.
+	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 }
 
 // NewGroupAddressServiceClient constructs a client for the knx.groupaddress.v1.GroupAddressService
@@ -87,14 +152,91 @@ func NewGroupAddressServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(groupAddressServiceMethods.ByName("SubscribeUnary")),
 			connect.WithClientOptions(opts...),
 		),
+		scan: connect.NewClient[v1.ScanRequest, v1.ScanResponse](
+			httpClient,
+			baseURL+GroupAddressServiceScanProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("Scan")),
+			connect.WithClientOptions(opts...),
+		),
+		listScheduled: connect.NewClient[v1.ListScheduledRequest, v1.ListScheduledResponse](
+			httpClient,
+			baseURL+GroupAddressServiceListScheduledProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("ListScheduled")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelScheduled: connect.NewClient[v1.CancelScheduledRequest, v1.CancelScheduledResponse](
+			httpClient,
+			baseURL+GroupAddressServiceCancelScheduledProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("CancelScheduled")),
+			connect.WithClientOptions(opts...),
+		),
+		transaction: connect.NewClient[v1.TransactionRequest, v1.TransactionResponse](
+			httpClient,
+			baseURL+GroupAddressServiceTransactionProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("Transaction")),
+			connect.WithClientOptions(opts...),
+		),
+		commitTransaction: connect.NewClient[v1.CommitTransactionRequest, v1.CommitTransactionResponse](
+			httpClient,
+			baseURL+GroupAddressServiceCommitTransactionProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("CommitTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		revertTransaction: connect.NewClient[v1.RevertTransactionRequest, v1.RevertTransactionResponse](
+			httpClient,
+			baseURL+GroupAddressServiceRevertTransactionProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("RevertTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		aggregate: connect.NewClient[v1.AggregateRequest, v1.AggregateResponse](
+			httpClient,
+			baseURL+GroupAddressServiceAggregateProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("Aggregate")),
+			connect.WithClientOptions(opts...),
+		),
+		listGroupAddresses: connect.NewClient[v1.ListGroupAddressesRequest, v1.ListGroupAddressesResponse](
+			httpClient,
+			baseURL+GroupAddressServiceListGroupAddressesProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("ListGroupAddresses")),
+			connect.WithClientOptions(opts...),
+		),
+		listSubscribers: connect.NewClient[v1.ListSubscribersRequest, v1.ListSubscribersResponse](
+			httpClient,
+			baseURL+GroupAddressServiceListSubscribersProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("ListSubscribers")),
+			connect.WithClientOptions(opts...),
+		),
+		subscribeAcked: connect.NewClient[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse](
+			httpClient,
+			baseURL+GroupAddressServiceSubscribeAckedProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("SubscribeAcked")),
+			connect.WithClientOptions(opts...),
+		),
+		getStatus: connect.NewClient[v1.GetStatusRequest, v1.GetStatusResponse](
+			httpClient,
+			baseURL+GroupAddressServiceGetStatusProcedure,
+			connect.WithSchema(groupAddressServiceMethods.ByName("GetStatus")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // groupAddressServiceClient implements GroupAddressServiceClient.
 type groupAddressServiceClient struct {
-	publish        *connect.Client[v1.PublishRequest, v1.PublishResponse]
-	subscribe      *connect.Client[v1.SubscribeRequest, v1.SubscribeResponse]
-	subscribeUnary *connect.Client[v1.SubscribeUnaryRequest, v1.SubscribeUnaryResponse]
+	publish            *connect.Client[v1.PublishRequest, v1.PublishResponse]
+	subscribe          *connect.Client[v1.SubscribeRequest, v1.SubscribeResponse]
+	subscribeUnary     *connect.Client[v1.SubscribeUnaryRequest, v1.SubscribeUnaryResponse]
+	scan               *connect.Client[v1.ScanRequest, v1.ScanResponse]
+	listScheduled      *connect.Client[v1.ListScheduledRequest, v1.ListScheduledResponse]
+	cancelScheduled    *connect.Client[v1.CancelScheduledRequest, v1.CancelScheduledResponse]
+	transaction        *connect.Client[v1.TransactionRequest, v1.TransactionResponse]
+	commitTransaction  *connect.Client[v1.CommitTransactionRequest, v1.CommitTransactionResponse]
+	revertTransaction  *connect.Client[v1.RevertTransactionRequest, v1.RevertTransactionResponse]
+	aggregate          *connect.Client[v1.AggregateRequest, v1.AggregateResponse]
+	listGroupAddresses *connect.Client[v1.ListGroupAddressesRequest, v1.ListGroupAddressesResponse]
+	listSubscribers    *connect.Client[v1.ListSubscribersRequest, v1.ListSubscribersResponse]
+	subscribeAcked     *connect.Client[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse]
+	getStatus          *connect.Client[v1.GetStatusRequest, v1.GetStatusResponse]
 }
 
 // Publish calls knx.groupaddress.v1.GroupAddressService.Publish.
@@ -112,6 +254,61 @@ func (c *groupAddressServiceClient) SubscribeUnary(ctx context.Context, req *con
 	return c.subscribeUnary.CallUnary(ctx, req)
 }
 
+// Scan calls knx.groupaddress.v1.GroupAddressService.Scan.
+func (c *groupAddressServiceClient) Scan(ctx context.Context, req *connect.Request[v1.ScanRequest]) (*connect.Response[v1.ScanResponse], error) {
+	return c.scan.CallUnary(ctx, req)
+}
+
+// ListScheduled calls knx.groupaddress.v1.GroupAddressService.ListScheduled.
+func (c *groupAddressServiceClient) ListScheduled(ctx context.Context, req *connect.Request[v1.ListScheduledRequest]) (*connect.Response[v1.ListScheduledResponse], error) {
+	return c.listScheduled.CallUnary(ctx, req)
+}
+
+// CancelScheduled calls knx.groupaddress.v1.GroupAddressService.CancelScheduled.
+func (c *groupAddressServiceClient) CancelScheduled(ctx context.Context, req *connect.Request[v1.CancelScheduledRequest]) (*connect.Response[v1.CancelScheduledResponse], error) {
+	return c.cancelScheduled.CallUnary(ctx, req)
+}
+
+// Transaction calls knx.groupaddress.v1.GroupAddressService.Transaction.
+func (c *groupAddressServiceClient) Transaction(ctx context.Context, req *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error) {
+	return c.transaction.CallUnary(ctx, req)
+}
+
+// CommitTransaction calls knx.groupaddress.v1.GroupAddressService.CommitTransaction.
+func (c *groupAddressServiceClient) CommitTransaction(ctx context.Context, req *connect.Request[v1.CommitTransactionRequest]) (*connect.Response[v1.CommitTransactionResponse], error) {
+	return c.commitTransaction.CallUnary(ctx, req)
+}
+
+// RevertTransaction calls knx.groupaddress.v1.GroupAddressService.RevertTransaction.
+func (c *groupAddressServiceClient) RevertTransaction(ctx context.Context, req *connect.Request[v1.RevertTransactionRequest]) (*connect.Response[v1.RevertTransactionResponse], error) {
+	return c.revertTransaction.CallUnary(ctx, req)
+}
+
+// Aggregate calls knx.groupaddress.v1.GroupAddressService.Aggregate.
+func (c *groupAddressServiceClient) Aggregate(ctx context.Context, req *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error) {
+	return c.aggregate.CallUnary(ctx, req)
+}
+
+// ListGroupAddresses calls knx.groupaddress.v1.GroupAddressService.ListGroupAddresses.
+func (c *groupAddressServiceClient) ListGroupAddresses(ctx context.Context, req *connect.Request[v1.ListGroupAddressesRequest]) (*connect.Response[v1.ListGroupAddressesResponse], error) {
+	return c.listGroupAddresses.CallUnary(ctx, req)
+}
+
+// ListSubscribers calls knx.groupaddress.v1.GroupAddressService.ListSubscribers.
+func (c *groupAddressServiceClient) ListSubscribers(ctx context.Context, req *connect.Request[v1.ListSubscribersRequest]) (*connect.Response[v1.ListSubscribersResponse], error) {
+	return c.listSubscribers.CallUnary(ctx, req)
+}
+
+// SubscribeAcked calls knx.groupaddress.v1.GroupAddressService.SubscribeAcked.
+func (c *groupAddressServiceClient) SubscribeAcked(ctx context.Context) *connect.BidiStreamForClient[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse] {
+	return c.subscribeAcked.CallBidiStream(ctx)
+}
+
+// GetStatus calls knx.groupaddress.v1.GroupAddressService.GetStatus.
+func (c *groupAddressServiceClient) GetStatus(ctx context.Context, req *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
+	return c.getStatus.CallUnary(ctx, req)
+}
+
 // GroupAddressServiceHandler is an implementation of the knx.groupaddress.v1.GroupAddressService
 // service.
 type GroupAddressServiceHandler interface {
@@ -125,6 +322,38 @@ type GroupAddressServiceHandler interface {
 	// Bus messages are delivered as an array of wrapped streamed responses.
 	// It is up to you to react on a message or ignore it.
 	SubscribeUnary(context.Context, *connect.Request[v1.SubscribeUnaryRequest]) (*connect.Response[v1.SubscribeUnaryResponse], error)
+	// Scan reads a range of group addresses with pacing and reports which
+	// of them answered. Useful for verifying new installations.
+	Scan(context.Context, *connect.Request[v1.ScanRequest]) (*connect.Response[v1.ScanResponse], error)
+	// ListScheduled lists publishes which are scheduled for a later time
+	ListScheduled(context.Context, *connect.Request[v1.ListScheduledRequest]) (*connect.Response[v1.ListScheduledResponse], error)
+	// CancelScheduled cancels a scheduled publish
+	CancelScheduled(context.Context, *connect.Request[v1.CancelScheduledRequest]) (*connect.Response[v1.CancelScheduledResponse], error)
+	// Transaction reads the current values of group addresses and writes new
+	// values. The previous values are restored using RevertTransaction or
+	// automatically once the transaction expires.
+	Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error)
+	// CommitTransaction keeps the written values and forgets the previous values
+	CommitTransaction(context.Context, *connect.Request[v1.CommitTransactionRequest]) (*connect.Response[v1.CommitTransactionResponse], error)
+	// RevertTransaction restores the previous values of a transaction
+	RevertTransaction(context.Context, *connect.Request[v1.RevertTransactionRequest]) (*connect.Response[v1.RevertTransactionResponse], error)
+	// Aggregate returns statistics of numeric values of a group address
+	// recorded by the history over time buckets.
+	Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error)
+	// ListGroupAddresses lists the configured group addresses merged with
+	// all group addresses seen on the bus including their last value.
+	ListGroupAddresses(context.Context, *connect.Request[v1.ListGroupAddressesRequest]) (*connect.Response[v1.ListGroupAddressesResponse], error)
+	// ListSubscribers lists the open Subscribe streams and their statistics.
+	// The statistics are also sent as trailers once a stream closes.
+	ListSubscribers(context.Context, *connect.Request[v1.ListSubscribersRequest]) (*connect.Response[v1.ListSubscribersResponse], error)
+	// SubscribeAcked is Subscribe with at-least-once delivery for a named consumer.
+	// Messages are retained until acknowledged and redelivered after reconnecting,
+	// the consumer keeps receiving while disconnected within rpc.ack.retention.
+	// A new stream of a connected consumer replaces its previous stream.
+	// Requires HTTP/2.
+	SubscribeAcked(context.Context, *connect.BidiStream[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse]) error
+	// GetStatus returns the state of the KNX tunnel.
+	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 }
 
 // NewGroupAddressServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -152,6 +381,72 @@ func NewGroupAddressServiceHandler(svc GroupAddressServiceHandler, opts ...conne
 		connect.WithSchema(groupAddressServiceMethods.ByName("SubscribeUnary")),
 		connect.WithHandlerOptions(opts...),
 	)
+	groupAddressServiceScanHandler := connect.NewUnaryHandler(
+		GroupAddressServiceScanProcedure,
+		svc.Scan,
+		connect.WithSchema(groupAddressServiceMethods.ByName("Scan")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceListScheduledHandler := connect.NewUnaryHandler(
+		GroupAddressServiceListScheduledProcedure,
+		svc.ListScheduled,
+		connect.WithSchema(groupAddressServiceMethods.ByName("ListScheduled")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceCancelScheduledHandler := connect.NewUnaryHandler(
+		GroupAddressServiceCancelScheduledProcedure,
+		svc.CancelScheduled,
+		connect.WithSchema(groupAddressServiceMethods.ByName("CancelScheduled")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceTransactionHandler := connect.NewUnaryHandler(
+		GroupAddressServiceTransactionProcedure,
+		svc.Transaction,
+		connect.WithSchema(groupAddressServiceMethods.ByName("Transaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceCommitTransactionHandler := connect.NewUnaryHandler(
+		GroupAddressServiceCommitTransactionProcedure,
+		svc.CommitTransaction,
+		connect.WithSchema(groupAddressServiceMethods.ByName("CommitTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceRevertTransactionHandler := connect.NewUnaryHandler(
+		GroupAddressServiceRevertTransactionProcedure,
+		svc.RevertTransaction,
+		connect.WithSchema(groupAddressServiceMethods.ByName("RevertTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceAggregateHandler := connect.NewUnaryHandler(
+		GroupAddressServiceAggregateProcedure,
+		svc.Aggregate,
+		connect.WithSchema(groupAddressServiceMethods.ByName("Aggregate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceListGroupAddressesHandler := connect.NewUnaryHandler(
+		GroupAddressServiceListGroupAddressesProcedure,
+		svc.ListGroupAddresses,
+		connect.WithSchema(groupAddressServiceMethods.ByName("ListGroupAddresses")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceListSubscribersHandler := connect.NewUnaryHandler(
+		GroupAddressServiceListSubscribersProcedure,
+		svc.ListSubscribers,
+		connect.WithSchema(groupAddressServiceMethods.ByName("ListSubscribers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceSubscribeAckedHandler := connect.NewBidiStreamHandler(
+		GroupAddressServiceSubscribeAckedProcedure,
+		svc.SubscribeAcked,
+		connect.WithSchema(groupAddressServiceMethods.ByName("SubscribeAcked")),
+		connect.WithHandlerOptions(opts...),
+	)
+	groupAddressServiceGetStatusHandler := connect.NewUnaryHandler(
+		GroupAddressServiceGetStatusProcedure,
+		svc.GetStatus,
+		connect.WithSchema(groupAddressServiceMethods.ByName("GetStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/knx.groupaddress.v1.GroupAddressService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case GroupAddressServicePublishProcedure:
@@ -160,6 +455,28 @@ func NewGroupAddressServiceHandler(svc GroupAddressServiceHandler, opts ...conne
 			groupAddressServiceSubscribeHandler.ServeHTTP(w, r)
 		case GroupAddressServiceSubscribeUnaryProcedure:
 			groupAddressServiceSubscribeUnaryHandler.ServeHTTP(w, r)
+		case GroupAddressServiceScanProcedure:
+			groupAddressServiceScanHandler.ServeHTTP(w, r)
+		case GroupAddressServiceListScheduledProcedure:
+			groupAddressServiceListScheduledHandler.ServeHTTP(w, r)
+		case GroupAddressServiceCancelScheduledProcedure:
+			groupAddressServiceCancelScheduledHandler.ServeHTTP(w, r)
+		case GroupAddressServiceTransactionProcedure:
+			groupAddressServiceTransactionHandler.ServeHTTP(w, r)
+		case GroupAddressServiceCommitTransactionProcedure:
+			groupAddressServiceCommitTransactionHandler.ServeHTTP(w, r)
+		case GroupAddressServiceRevertTransactionProcedure:
+			groupAddressServiceRevertTransactionHandler.ServeHTTP(w, r)
+		case GroupAddressServiceAggregateProcedure:
+			groupAddressServiceAggregateHandler.ServeHTTP(w, r)
+		case GroupAddressServiceListGroupAddressesProcedure:
+			groupAddressServiceListGroupAddressesHandler.ServeHTTP(w, r)
+		case GroupAddressServiceListSubscribersProcedure:
+			groupAddressServiceListSubscribersHandler.ServeHTTP(w, r)
+		case GroupAddressServiceSubscribeAckedProcedure:
+			groupAddressServiceSubscribeAckedHandler.ServeHTTP(w, r)
+		case GroupAddressServiceGetStatusProcedure:
+			groupAddressServiceGetStatusHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -180,3 +497,47 @@ func (UnimplementedGroupAddressServiceHandler) Subscribe(context.Context, *conne
 func (UnimplementedGroupAddressServiceHandler) SubscribeUnary(context.Context, *connect.Request[v1.SubscribeUnaryRequest]) (*connect.Response[v1.SubscribeUnaryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.SubscribeUnary is not implemented"))
 }
+
+func (UnimplementedGroupAddressServiceHandler) Scan(context.Context, *connect.Request[v1.ScanRequest]) (*connect.Response[v1.ScanResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.Scan is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) ListScheduled(context.Context, *connect.Request[v1.ListScheduledRequest]) (*connect.Response[v1.ListScheduledResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.ListScheduled is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) CancelScheduled(context.Context, *connect.Request[v1.CancelScheduledRequest]) (*connect.Response[v1.CancelScheduledResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.CancelScheduled is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) Transaction(context.Context, *connect.Request[v1.TransactionRequest]) (*connect.Response[v1.TransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.Transaction is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) CommitTransaction(context.Context, *connect.Request[v1.CommitTransactionRequest]) (*connect.Response[v1.CommitTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.CommitTransaction is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) RevertTransaction(context.Context, *connect.Request[v1.RevertTransactionRequest]) (*connect.Response[v1.RevertTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.RevertTransaction is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) Aggregate(context.Context, *connect.Request[v1.AggregateRequest]) (*connect.Response[v1.AggregateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.Aggregate is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) ListGroupAddresses(context.Context, *connect.Request[v1.ListGroupAddressesRequest]) (*connect.Response[v1.ListGroupAddressesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.ListGroupAddresses is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) ListSubscribers(context.Context, *connect.Request[v1.ListSubscribersRequest]) (*connect.Response[v1.ListSubscribersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.ListSubscribers is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) SubscribeAcked(context.Context, *connect.BidiStream[v1.SubscribeAckedRequest, v1.SubscribeAckedResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.SubscribeAcked is not implemented"))
+}
+
+func (UnimplementedGroupAddressServiceHandler) GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("knx.groupaddress.v1.GroupAddressService.GetStatus is not implemented"))
+}
@@ -0,0 +1,606 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrLDAPInvalidCredentials = errors.New("invalid ldap credentials")
+	ErrLDAPNoRole             = errors.New("ldap user has no role")
+)
+
+// LDAPConfig holds the LDAP/Active Directory authentication config
+type LDAPConfig struct {
+	// Enabled whether to authenticate HTTP Basic credentials using LDAP
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// URL of the directory server, required
+	// valid format: ldap://host:389, ldaps://host:636
+	URL string `mapstructure:"url"`
+
+	// InsecureTLS whether to skip verifying the server certificate
+	InsecureTLS bool `mapstructure:"insecureTLS" default:"false"`
+
+	// BindDN is the template to bind as the user, {username} is replaced, required
+	// e.g.: uid={username},ou=people,dc=example,dc=com or {username}@corp.example.com
+	BindDN string `mapstructure:"bindDN"`
+
+	// BaseDN to search the user entry in, required
+	BaseDN string `mapstructure:"baseDN"`
+
+	// UserFilter is the equality filter to find the user entry, {username} is replaced
+	UserFilter string `mapstructure:"userFilter" default:"(uid={username})"`
+
+	// GroupAttribute is the attribute of the user entry listing its groups
+	GroupAttribute string `mapstructure:"groupAttribute" default:"memberOf"`
+
+	// Roles maps group DNs to roles, the highest role of all groups is used, required
+	Roles []LDAPRoleConfig `mapstructure:"roles"`
+
+	// Timeout for directory operations
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+
+	// CacheTTL is the duration successful logins are cached for (0 disables it)
+	CacheTTL time.Duration `mapstructure:"cacheTTL" default:"1m"`
+}
+
+// LDAPRoleConfig maps a group to a role
+type LDAPRoleConfig struct {
+	// Group is the DN of the group, compared case insensitive, required
+	Group string `mapstructure:"group"`
+
+	// Role of group members, oneof: admin|writer|reader
+	Role Role `mapstructure:"role"`
+}
+
+// Validate validates the LDAPConfig
+func (c *LDAPConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid rpc.auth.ldap.url: %s", err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return fmt.Errorf("invalid rpc.auth.ldap.url scheme: %s", u.Scheme)
+	}
+	if !strings.Contains(c.BindDN, "{username}") {
+		return fmt.Errorf("rpc.auth.ldap.bindDN must contain {username}")
+	}
+	if len(c.BaseDN) == 0 {
+		return fmt.Errorf("missing rpc.auth.ldap.baseDN")
+	}
+	if _, _, err := parseLDAPEqualityFilter(c.UserFilter); err != nil {
+		return fmt.Errorf("invalid rpc.auth.ldap.userFilter: %s", err)
+	}
+	if len(c.GroupAttribute) == 0 {
+		return fmt.Errorf("missing rpc.auth.ldap.groupAttribute")
+	}
+	if len(c.Roles) == 0 {
+		return fmt.Errorf("missing rpc.auth.ldap.roles")
+	}
+	for _, r := range c.Roles {
+		if len(r.Group) == 0 {
+			return fmt.Errorf("missing rpc.auth.ldap.roles.group")
+		}
+		if r.Role.level() == 0 {
+			return fmt.Errorf("invalid rpc.auth.ldap.roles(%s).role: %s", r.Group, r.Role)
+		}
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("invalid rpc.auth.ldap.timeout")
+	}
+
+	return nil
+}
+
+// parseLDAPEqualityFilter returns attribute and value of a filter like (uid={username})
+func parseLDAPEqualityFilter(filter string) (string, string, error) {
+	if !strings.HasPrefix(filter, "(") || !strings.HasSuffix(filter, ")") {
+		return "", "", errors.New("filter must be enclosed in parentheses")
+	}
+	attr, value, ok := strings.Cut(filter[1:len(filter)-1], "=")
+	if !ok || len(attr) == 0 || strings.ContainsAny(attr, "()&|!*~<>") {
+		return "", "", errors.New("only equality filters like (uid={username}) are supported")
+	}
+
+	return attr, value, nil
+}
+
+// ldapLogin is a cached successful login
+type ldapLogin struct {
+	key     *apiKey
+	expires time.Time
+}
+
+// ldapAuthenticator authenticates users against a directory
+type ldapAuthenticator struct {
+	config *LDAPConfig
+
+	// logins caches successful logins by sha256 of username and password
+	logins map[[sha256.Size]byte]ldapLogin
+	// m_logins synchronizes access to logins
+	m_logins sync.Mutex
+}
+
+// newLDAPAuthenticator returns a *ldapAuthenticator of config, nil if disabled
+func newLDAPAuthenticator(config *LDAPConfig) *ldapAuthenticator {
+	if !config.Enabled {
+		return nil
+	}
+
+	return &ldapAuthenticator{
+		config: config,
+		logins: map[[sha256.Size]byte]ldapLogin{},
+	}
+}
+
+// authenticate binds as username using password, looks up its groups and
+// returns an *apiKey named ldap:<username> having the highest mapped role.
+func (a *ldapAuthenticator) authenticate(ctx context.Context, username, password string) (*apiKey, error) {
+	// an empty password would be an unauthenticated bind which always succeeds
+	if len(username) == 0 || len(password) == 0 {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	cacheKey := sha256.Sum256([]byte(username + "\x00" + password))
+	a.m_logins.Lock()
+	login, ok := a.logins[cacheKey]
+	if ok && time.Now().After(login.expires) {
+		delete(a.logins, cacheKey)
+		ok = false
+	}
+	a.m_logins.Unlock()
+	if ok {
+		return login.key, nil
+	}
+
+	groups, err := a.lookupGroups(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	role := Role("")
+	for _, group := range groups {
+		for _, r := range a.config.Roles {
+			if strings.EqualFold(group, r.Group) && r.Role.level() > role.level() {
+				role = r.Role
+			}
+		}
+	}
+	if role.level() == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLDAPNoRole, username)
+	}
+
+	key := &apiKey{
+		config: &APIKeyConfig{
+			Name: "ldap:" + username,
+			Role: role,
+		},
+	}
+	if a.config.CacheTTL > 0 {
+		a.m_logins.Lock()
+		a.logins[cacheKey] = ldapLogin{
+			key:     key,
+			expires: time.Now().Add(a.config.CacheTTL),
+		}
+		a.m_logins.Unlock()
+	}
+
+	return key, nil
+}
+
+// lookupGroups binds as username and returns the values of the group attribute
+func (a *ldapAuthenticator) lookupGroups(ctx context.Context, username, password string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	conn, err := a.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ldap connect: %s", err)
+	}
+	defer conn.Close() // nolint:errcheck
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) // nolint:errcheck
+	}
+	c := &ldapConn{conn: conn, r: bufio.NewReader(conn)}
+
+	bindDN := strings.ReplaceAll(a.config.BindDN, "{username}", ldapEscapeDN(username))
+	if err := c.bind(bindDN, password); err != nil {
+		return nil, err
+	}
+
+	attr, value, _ := parseLDAPEqualityFilter(a.config.UserFilter)
+	value = strings.ReplaceAll(value, "{username}", username)
+	groups, err := c.searchAttribute(a.config.BaseDN, attr, value, a.config.GroupAttribute)
+	if err != nil {
+		return nil, err
+	}
+	c.unbind()
+
+	return groups, nil
+}
+
+// dial connects to the configured directory server
+func (a *ldapAuthenticator) dial(ctx context.Context) (net.Conn, error) {
+	u, err := url.Parse(a.config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if len(u.Port()) == 0 {
+		port := "389"
+		if u.Scheme == "ldaps" {
+			port = "636"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	if u.Scheme == "ldaps" {
+		d := &tls.Dialer{Config: &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: a.config.InsecureTLS, // nolint:gosec
+		}}
+		return d.DialContext(ctx, "tcp", host)
+	}
+
+	d := &net.Dialer{}
+	return d.DialContext(ctx, "tcp", host)
+}
+
+// ldapEscapeDN escapes special characters of a DN attribute value
+func ldapEscapeDN(s string) string {
+	b := strings.Builder{}
+	for i, r := range s {
+		switch {
+		case strings.ContainsRune(",+\"\\<>;=", r),
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(s)-1 && r == ' ':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// LDAP protocol tags
+const (
+	ldapTagBindRequest       = 0x60
+	ldapTagBindResponse      = 0x61
+	ldapTagUnbindRequest     = 0x42
+	ldapTagSearchRequest     = 0x63
+	ldapTagSearchResultEntry = 0x64
+	ldapTagSearchResultDone  = 0x65
+	ldapTagSearchResultRef   = 0x73
+	ldapTagFilterEquality    = 0xa3
+	ldapTagSimpleAuth        = 0x80
+
+	ldapResultSuccess            = 0
+	ldapResultInvalidCredentials = 49
+)
+
+// ldapConn is a minimal LDAPv3 client connection supporting bind and search
+type ldapConn struct {
+	conn      net.Conn
+	r         *bufio.Reader
+	messageID int
+}
+
+// send writes an LDAPMessage having op
+func (c *ldapConn) send(op []byte) error {
+	c.messageID++
+	_, err := c.conn.Write(berTLV(0x30, berInt(c.messageID), op))
+	return err
+}
+
+// receive returns the tag and content of the protocol op of the next LDAPMessage
+func (c *ldapConn) receive() (byte, []byte, error) {
+	tag, msg, err := berReadTLV(c.r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if tag != 0x30 {
+		return 0, nil, fmt.Errorf("unexpected ldap message tag 0x%02x", tag)
+	}
+
+	// skip messageID
+	if _, _, msg, err = berParseTLV(msg); err != nil {
+		return 0, nil, err
+	}
+	tag, op, _, err := berParseTLV(msg)
+
+	return tag, op, err
+}
+
+// bind authenticates as dn using password
+func (c *ldapConn) bind(dn, password string) error {
+	err := c.send(berTLV(ldapTagBindRequest,
+		berInt(3),
+		berTLV(0x04, []byte(dn)),
+		berTLV(ldapTagSimpleAuth, []byte(password)),
+	))
+	if err != nil {
+		return err
+	}
+
+	tag, op, err := c.receive()
+	if err != nil {
+		return err
+	}
+	if tag != ldapTagBindResponse {
+		return fmt.Errorf("unexpected ldap bind response tag 0x%02x", tag)
+	}
+	code, msg, err := ldapResult(op)
+	if err != nil {
+		return err
+	}
+	switch code {
+	case ldapResultSuccess:
+		return nil
+	case ldapResultInvalidCredentials:
+		return ErrLDAPInvalidCredentials
+	}
+
+	return fmt.Errorf("ldap bind failed: %d %s", code, msg)
+}
+
+// searchAttribute returns the values of attribute of the single entry
+// below baseDN having filterAttr equal to filterValue.
+func (c *ldapConn) searchAttribute(baseDN, filterAttr, filterValue, attribute string) ([]string, error) {
+	err := c.send(berTLV(ldapTagSearchRequest,
+		berTLV(0x04, []byte(baseDN)),
+		berTLV(0x0a, []byte{2}), // scope wholeSubtree
+		berTLV(0x0a, []byte{0}), // neverDerefAliases
+		berInt(2),               // sizeLimit
+		berInt(0),               // timeLimit
+		berTLV(0x01, []byte{0}), // typesOnly
+		berTLV(ldapTagFilterEquality,
+			berTLV(0x04, []byte(filterAttr)),
+			berTLV(0x04, []byte(filterValue)),
+		),
+		berTLV(0x30, berTLV(0x04, []byte(attribute))),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := 0
+	values := []string{}
+	for {
+		tag, op, err := c.receive()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tag {
+		case ldapTagSearchResultEntry:
+			entries++
+			vals, err := ldapEntryAttribute(op, attribute)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, vals...)
+		case ldapTagSearchResultRef:
+			// referrals are not followed
+		case ldapTagSearchResultDone:
+			code, msg, err := ldapResult(op)
+			if err != nil {
+				return nil, err
+			}
+			if code != ldapResultSuccess {
+				return nil, fmt.Errorf("ldap search failed: %d %s", code, msg)
+			}
+			if entries != 1 {
+				return nil, fmt.Errorf("ldap search returned %d entries", entries)
+			}
+			return values, nil
+		default:
+			return nil, fmt.Errorf("unexpected ldap search response tag 0x%02x", tag)
+		}
+	}
+}
+
+// unbind closes the session
+func (c *ldapConn) unbind() {
+	c.send([]byte{ldapTagUnbindRequest, 0}) // nolint:errcheck
+}
+
+// ldapResult returns resultCode and diagnosticMessage of an LDAPResult
+func ldapResult(op []byte) (int, string, error) {
+	_, code, rest, err := berParseTLV(op)
+	if err != nil {
+		return 0, "", err
+	}
+	// skip matchedDN
+	if _, _, rest, err = berParseTLV(rest); err != nil {
+		return 0, "", err
+	}
+	_, msg, _, err := berParseTLV(rest)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return int(berParseInt(code)), string(msg), nil
+}
+
+// ldapEntryAttribute returns the values of attribute of a SearchResultEntry
+func ldapEntryAttribute(op []byte, attribute string) ([]string, error) {
+	// skip objectName
+	_, _, rest, err := berParseTLV(op)
+	if err != nil {
+		return nil, err
+	}
+	_, attrs, _, err := berParseTLV(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []string{}
+	for len(attrs) > 0 {
+		var attr []byte
+		if _, attr, attrs, err = berParseTLV(attrs); err != nil {
+			return nil, err
+		}
+		_, name, vals, err := berParseTLV(attr)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(string(name), attribute) {
+			continue
+		}
+		_, vals, _, err = berParseTLV(vals)
+		if err != nil {
+			return nil, err
+		}
+		for len(vals) > 0 {
+			var val []byte
+			if _, val, vals, err = berParseTLV(vals); err != nil {
+				return nil, err
+			}
+			ret = append(ret, string(val))
+		}
+	}
+
+	return ret, nil
+}
+
+// berTLV returns a BER encoded tag, length and the concatenated contents
+func berTLV(tag byte, contents ...[]byte) []byte {
+	n := 0
+	for _, c := range contents {
+		n += len(c)
+	}
+
+	ret := []byte{tag}
+	switch {
+	case n < 0x80:
+		ret = append(ret, byte(n))
+	case n <= 0xff:
+		ret = append(ret, 0x81, byte(n))
+	case n <= 0xffff:
+		ret = append(ret, 0x82, byte(n>>8), byte(n))
+	default:
+		ret = append(ret, 0x84, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	for _, c := range contents {
+		ret = append(ret, c...)
+	}
+
+	return ret
+}
+
+// berInt returns a BER encoded INTEGER of a non-negative v
+func berInt(v int) []byte {
+	b := []byte{byte(v)}
+	for v > 0xff || (v > 0x7f && b[0]&0x80 != 0) {
+		v >>= 8
+		b = append([]byte{byte(v)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+
+	return berTLV(0x02, b)
+}
+
+// berParseInt returns the value of INTEGER or ENUMERATED contents
+func berParseInt(b []byte) int64 {
+	var v int64
+	for i, c := range b {
+		if i == 0 && c&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(c)
+	}
+
+	return v
+}
+
+// berParseTLV returns tag, contents and the remainder of b
+func berParseTLV(b []byte) (byte, []byte, []byte, error) {
+	if len(b) < 2 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	tag, n, hdr := b[0], int(b[1]), 2
+	if n&0x80 != 0 {
+		octets := n & 0x7f
+		if octets == 0 || octets > 4 || len(b) < 2+octets {
+			return 0, nil, nil, errors.New("invalid ber length")
+		}
+		n = 0
+		for _, c := range b[2 : 2+octets] {
+			n = n<<8 | int(c)
+		}
+		hdr += octets
+	}
+	if n < 0 || len(b) < hdr+n {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+
+	return tag, b[hdr : hdr+n], b[hdr+n:], nil
+}
+
+// berReadTLV reads a single BER element from r returning tag and contents
+func berReadTLV(r *bufio.Reader) (byte, []byte, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return 0, nil, err
+	}
+	n := int(hdr[1])
+	if n&0x80 != 0 {
+		octets := n & 0x7f
+		if octets == 0 || octets > 4 {
+			return 0, nil, errors.New("invalid ber length")
+		}
+		lb := make([]byte, octets)
+		if _, err := io.ReadFull(r, lb); err != nil {
+			return 0, nil, err
+		}
+		n = 0
+		for _, c := range lb {
+			n = n<<8 | int(c)
+		}
+	}
+	// ldap messages of this client are small, reject huge lengths
+	if n < 0 || n > 1<<24 {
+		return 0, nil, errors.New("ber element too large")
+	}
+
+	contents := make([]byte, n)
+	if _, err := io.ReadFull(r, contents); err != nil {
+		return 0, nil, err
+	}
+
+	return hdr[0], contents, nil
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package knxrpc
 
 import (
-	"fmt"
+	"reflect"
+	"strconv"
+	"time"
 
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v2 "github.com/choopm/knxrpc/knx/groupaddress/v2"
 	"github.com/vapourismo/knx-go/knx"
 	"github.com/vapourismo/knx-go/knx/cemi"
 )
@@ -27,8 +30,8 @@ import (
 // toV1SubscribeResponse returns the v1.SubscribeResponse of event
 func toV1SubscribeResponse(event *knx.GroupEvent) *v1.SubscribeResponse {
 	ret := &v1.SubscribeResponse{
-		GroupAddress:    event.Destination.String(),
-		PhysicalAddress: event.Source.String(),
+		GroupAddress:    groupAddrStrings.get(event.Destination),
+		PhysicalAddress: individualAddrStrings.get(event.Source),
 		Event:           v1.Event_EVENT_UNSPECIFIED,
 		Data:            event.Data,
 	}
@@ -50,7 +53,7 @@ func fromV1PublishRequest(req *v1.PublishRequest) (*knx.GroupEvent, error) {
 	// parse group address
 	ga, err := cemi.NewGroupAddrString(req.GroupAddress)
 	if err != nil {
-		return nil, fmt.Errorf("parse groupAddress: %s", err)
+		return nil, fieldErrorf("group_address", "parse groupAddress: %s", err)
 	}
 
 	event := &knx.GroupEvent{
@@ -75,8 +78,117 @@ func fromV1PublishRequest(req *v1.PublishRequest) (*knx.GroupEvent, error) {
 
 	event.Source, err = cemi.NewIndividualAddrString(req.PhysicalAddress)
 	if err != nil {
-		return nil, fmt.Errorf("parse physicalAddress: %s", err)
+		return nil, fieldErrorf("physical_address", "parse physicalAddress: %s", err)
 	}
 
 	return event, nil
 }
+
+// toV2Telegram returns the v2.Telegram of event including the
+// metadata of its directory entry, if any
+func toV2Telegram(event *knx.GroupEvent, entry *GroupAddressConfig) *v2.Telegram {
+	ret := &v2.Telegram{
+		GroupAddress:    groupAddrStrings.get(event.Destination),
+		PhysicalAddress: individualAddrStrings.get(event.Source),
+		Event:           v2.Event_EVENT_UNSPECIFIED,
+		Data:            event.Data,
+	}
+
+	switch event.Command {
+	case knx.GroupRead:
+		ret.Event = v2.Event_EVENT_READ
+	case knx.GroupResponse:
+		ret.Event = v2.Event_EVENT_RESPONSE
+	case knx.GroupWrite:
+		ret.Event = v2.Event_EVENT_WRITE
+	}
+
+	if entry == nil {
+		return ret
+	}
+	ret.Name = entry.Name
+	ret.Dpt = entry.DPT
+	if len(entry.DPT) > 0 && event.Command != knx.GroupRead {
+		ret.Value = toV2Value(entry.DPT, event.Data)
+	}
+
+	return ret
+}
+
+// toV2Value returns the v2.Value of data decoded using datapoint type
+// dptName, nil if it can not be decoded
+func toV2Value(dptName string, data []byte) *v2.Value {
+	v, text, ok := unpackValue(dptName, data)
+	if !ok {
+		return nil
+	}
+
+	ret := &v2.Value{Text: text}
+	switch v.Kind() {
+	case reflect.Bool:
+		ret.Kind = &v2.Value_BoolValue{BoolValue: v.Bool()}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ret.Kind = &v2.Value_IntValue{IntValue: v.Int()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		ret.Kind = &v2.Value_UintValue{UintValue: v.Uint()}
+	case reflect.Float32, reflect.Float64:
+		ret.Kind = &v2.Value_FloatValue{FloatValue: v.Float()}
+	default:
+		ret.Kind = &v2.Value_StringValue{StringValue: text}
+	}
+
+	return ret
+}
+
+// fromV2PublishRequest returns the v1.PublishRequest of req so that
+// both versions share the publish path
+func fromV2PublishRequest(req *v2.PublishRequest) (*v1.PublishRequest, error) {
+	ret := &v1.PublishRequest{
+		GroupAddress:    req.GroupAddress,
+		PhysicalAddress: req.PhysicalAddress,
+		Event:           v1.Event(req.Event),
+		Data:            req.Data,
+		Dpt:             req.Dpt,
+		IdempotencyKey:  req.IdempotencyKey,
+		Delay:           req.Delay,
+	}
+	if req.At != nil {
+		if err := req.At.CheckValid(); err != nil {
+			return nil, fieldErrorf("at", "invalid 'at': %s", err)
+		}
+		ret.At = req.At.AsTime().Format(time.RFC3339Nano)
+	}
+
+	if req.Value == nil {
+		return ret, nil
+	}
+	switch kind := req.Value.Kind.(type) {
+	case *v2.Value_BoolValue:
+		ret.Value = strconv.FormatBool(kind.BoolValue)
+	case *v2.Value_IntValue:
+		ret.Value = strconv.FormatInt(kind.IntValue, 10)
+	case *v2.Value_UintValue:
+		ret.Value = strconv.FormatUint(kind.UintValue, 10)
+	case *v2.Value_FloatValue:
+		ret.Value = strconv.FormatFloat(kind.FloatValue, 'g', -1, 64)
+	case *v2.Value_StringValue:
+		ret.Value = kind.StringValue
+	}
+	if len(ret.Value) == 0 {
+		return nil, fieldErrorf("value", "missing value")
+	}
+
+	return ret, nil
+}
+
+// fromV2ReplayLast returns the v1.ReplayLast of last, nil if unset
+func fromV2ReplayLast(last *v2.ReplayLast) *v1.ReplayLast {
+	if last == nil {
+		return nil
+	}
+
+	return &v1.ReplayLast{
+		Count:  last.Count,
+		Within: last.Within,
+	}
+}
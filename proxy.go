@@ -0,0 +1,282 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// proxyHeaderTimeout is the time to wait for a PROXY protocol header
+const proxyHeaderTimeout = 5 * time.Second
+
+// proxyV2Signature starts a binary PROXY protocol v2 header
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// trustedProxies holds the networks of reverse proxies in front of knxrpc
+type trustedProxies []netip.Prefix
+
+// parseTrustedProxies returns the trustedProxies of IPs or CIDRs or error
+func parseTrustedProxies(proxies []string) (trustedProxies, error) {
+	ret := trustedProxies{}
+	for i, p := range proxies {
+		if !strings.Contains(p, "/") {
+			addr, err := netip.ParseAddr(p)
+			if err != nil {
+				return nil, fmt.Errorf("trustedProxies(%d): %s", i, err)
+			}
+			ret = append(ret, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return nil, fmt.Errorf("trustedProxies(%d): %s", i, err)
+		}
+		ret = append(ret, prefix.Masked())
+	}
+
+	return ret, nil
+}
+
+// trusts returns whether the IP of addr, an IP or host:port, is a trusted proxy
+func (t trustedProxies) trusts(addr string) bool {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+
+	for _, prefix := range t {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// echoTrustOptions returns the options of an echo IPExtractor
+// trusting only t instead of echo's default private ranges
+func (t trustedProxies) echoTrustOptions() []echo.TrustOption {
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, prefix := range t {
+		opts = append(opts, echo.TrustIPRange(&net.IPNet{
+			IP:   prefix.Addr().AsSlice(),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		}))
+	}
+
+	return opts
+}
+
+// peerAddr returns the client address of a request received from addr.
+// Requests of trusted proxies are attributed to the last untrusted
+// address of their X-Forwarded-For header.
+func (t trustedProxies) peerAddr(addr string, header http.Header) string {
+	if !t.trusts(addr) {
+		return addr
+	}
+
+	// the header may be split into several lines
+	forwarded := []string{}
+	for _, line := range header.Values("X-Forwarded-For") {
+		for _, ip := range strings.Split(line, ",") {
+			forwarded = append(forwarded, strings.TrimSpace(ip))
+		}
+	}
+
+	// walk the chain from the nearest proxy to the client
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		if len(forwarded[i]) == 0 {
+			continue
+		}
+		addr = forwarded[i]
+		if !t.trusts(addr) {
+			break
+		}
+	}
+
+	return addr
+}
+
+// proxyProtocolListener accepts connections prefixed by a PROXY protocol
+// header if sent from trusted proxies
+type proxyProtocolListener struct {
+	net.Listener
+	trusted trustedProxies
+}
+
+// Accept implements net.Listener, the header is read on first use
+// of the connection so that a slow proxy does not block Accept.
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !l.trusted.trusts(conn.RemoteAddr().String()) {
+		return conn, nil
+	}
+
+	return &proxyProtocolConn{Conn: conn}, nil
+}
+
+// proxyProtocolConn is a net.Conn using the source address of its PROXY header
+type proxyProtocolConn struct {
+	net.Conn
+
+	// reader buffers the data read while parsing the header
+	reader *bufio.Reader
+	// remote is the source address of the header, nil for LOCAL
+	remote net.Addr
+	// err is the error parsing the header
+	err error
+	// once parses the header once
+	once sync.Once
+}
+
+// init parses the header
+func (c *proxyProtocolConn) init() {
+	c.once.Do(func() {
+		c.reader = bufio.NewReader(c.Conn)
+		c.Conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)) // nolint:errcheck
+		c.remote, c.err = readProxyHeader(c.reader)
+		c.Conn.SetReadDeadline(time.Time{}) // nolint:errcheck
+		if c.err != nil {
+			c.Conn.Close() // nolint:errcheck
+		}
+	})
+}
+
+// Read implements net.Conn
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.init()
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	return c.reader.Read(b)
+}
+
+// RemoteAddr implements net.Conn
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.init()
+	if c.remote != nil {
+		return c.remote
+	}
+
+	return c.Conn.RemoteAddr()
+}
+
+// readProxyHeader reads a PROXY protocol v1 or v2 header from r and returns
+// the source address, nil for LOCAL or UNKNOWN connections, or error.
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	sig, err := r.Peek(len(proxyV2Signature))
+	if err != nil {
+		return nil, fmt.Errorf("proxy protocol: %s", err)
+	}
+	if bytes.Equal(sig, proxyV2Signature) {
+		return readProxyHeaderV2(r)
+	}
+	if !bytes.HasPrefix(sig, []byte("PROXY ")) {
+		return nil, errors.New("proxy protocol: missing header")
+	}
+
+	// v1 is a single line of at most 107 bytes:
+	// PROXY TCP4|TCP6|UNKNOWN src dst sport dport\r\n
+	line, err := r.ReadSlice('\n')
+	if err != nil || len(line) > 107 || !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.New("proxy protocol: invalid v1 header")
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, errors.New("proxy protocol: invalid v1 header")
+	}
+	ip, err := netip.ParseAddr(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("proxy protocol: %s", err)
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("proxy protocol: %s", err)
+	}
+
+	return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip, uint16(port))), nil
+}
+
+// readProxyHeaderV2 reads a binary PROXY protocol v2 header from r
+func readProxyHeaderV2(r *bufio.Reader) (net.Addr, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("proxy protocol: %s", err)
+	}
+	if header[12]>>4 != 2 {
+		return nil, errors.New("proxy protocol: unsupported version")
+	}
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("proxy protocol: %s", err)
+	}
+
+	// LOCAL connections are health checks of the proxy itself
+	if header[12]&0x0f == 0 {
+		return nil, nil
+	}
+
+	switch header[13] >> 4 {
+	case 1: // AF_INET
+		if len(payload) < 12 {
+			return nil, errors.New("proxy protocol: short v2 address")
+		}
+		ip := netip.AddrFrom4([4]byte(payload[0:4]))
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip,
+			binary.BigEndian.Uint16(payload[8:10]))), nil
+	case 2: // AF_INET6
+		if len(payload) < 36 {
+			return nil, errors.New("proxy protocol: short v2 address")
+		}
+		ip := netip.AddrFrom16([16]byte(payload[0:16]))
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip,
+			binary.BigEndian.Uint16(payload[32:34]))), nil
+	}
+
+	// AF_UNSPEC or AF_UNIX
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrStreamQuotaExhausted         = errors.New("subscriber quota exhausted")
+	ErrIdentityStreamQuotaExhausted = errors.New("subscriber quota of identity exhausted")
+)
+
+// QuotaConfig holds limits protecting the server from runaway clients
+type QuotaConfig struct {
+	// MaxSubscribers limits concurrent Subscribe streams of all clients, optional (0 means unlimited)
+	MaxSubscribers int `mapstructure:"maxSubscribers" default:"0"`
+
+	// MaxSubscribersPerIdentity limits concurrent Subscribe streams per authenticated
+	// identity, optional (0 means unlimited). Every tenant is an identity, the
+	// rpc.auth.secretKey and unauthenticated clients share a single identity.
+	MaxSubscribersPerIdentity int `mapstructure:"maxSubscribersPerIdentity" default:"0"`
+}
+
+// Validate validates the QuotaConfig
+func (c *QuotaConfig) Validate() error {
+	if c.MaxSubscribers < 0 {
+		return fmt.Errorf("invalid rpc.quota.maxSubscribers")
+	}
+	if c.MaxSubscribersPerIdentity < 0 {
+		return fmt.Errorf("invalid rpc.quota.maxSubscribersPerIdentity")
+	}
+
+	return nil
+}
+
+// acquireStream reserves a Subscribe stream for identity or returns an error
+// wrapping ErrStreamQuotaExhausted. The returned func must be called to release it.
+func (s *Server) acquireStream(identity string) (func(), error) {
+	s.m_streams.Lock()
+	defer s.m_streams.Unlock()
+
+	quota := s.reloadable().quota
+	if quota.MaxSubscribers > 0 && s.streams >= quota.MaxSubscribers {
+		return nil, ErrStreamQuotaExhausted
+	}
+	if quota.MaxSubscribersPerIdentity > 0 &&
+		s.identityStreams[identity] >= quota.MaxSubscribersPerIdentity {
+		return nil, ErrIdentityStreamQuotaExhausted
+	}
+
+	s.streams++
+	s.identityStreams[identity]++
+
+	return func() {
+		s.m_streams.Lock()
+		defer s.m_streams.Unlock()
+
+		s.streams--
+		s.identityStreams[identity]--
+		if s.identityStreams[identity] == 0 {
+			delete(s.identityStreams, identity)
+		}
+	}, nil
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vapourismo/knx-go/knx/knxnet"
+)
+
+// relay forwards a tunnel connected to a loopback address to the gateway.
+// The knx library always dials IPv4, lets the system choose the source
+// address and resolves the gateway once, so IPv6 gateways, binding to a
+// local address and UDP gateways given by hostname are relayed.
+type relay interface {
+	// addr returns the loopback address the tunnel has to connect to
+	addr() string
+
+	// Close stops the relay
+	Close() error
+}
+
+// relayGateway returns the address to connect the tunnel of config to.
+// It starts a relay if required, which has to be closed after the tunnel.
+func relayGateway(config *KNXConfig) (string, relay, error) {
+	// prefer the address family of knx.localAddress
+	network := "ip"
+	if ip := net.ParseIP(config.LocalAddress); ip != nil {
+		network = "ip6"
+		if ip.To4() != nil {
+			network = "ip4"
+		}
+	}
+	ip, err := net.ResolveIPAddr(network, config.GatwewayHost)
+	if err != nil {
+		return "", nil, err
+	}
+	ipv6 := ip.IP.To4() == nil
+
+	laddr, err := localBindAddress(config, ipv6)
+	if err != nil {
+		return "", nil, err
+	}
+	// hostnames are re-resolved by reconnects of UDP tunnels, TCP tunnels
+	// do not reconnect without a new connection and thereby resolving again
+	hostname := net.ParseIP(config.GatwewayHost) == nil
+	if laddr == nil && !ipv6 && (!hostname || config.UseTCP) {
+		return gatewayAddress(config), nil, nil
+	}
+
+	var r relay
+	if config.UseTCP {
+		gateway := net.JoinHostPort(ip.String(), strconv.Itoa(config.GatwewayPort))
+		r, err = newTCPRelay(laddr, gateway)
+	} else {
+		gateway := &net.UDPAddr{IP: ip.IP, Zone: ip.Zone, Port: config.GatwewayPort}
+		resolve := ""
+		if hostname {
+			resolve = config.GatwewayHost
+		}
+		r, err = newUDPRelay(laddr, gateway, resolve, config.SendLocalAddress)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	return r.addr(), r, nil
+}
+
+// localBindAddress returns the address to bind the tunnel to from
+// knx.localAddress or knx.interface, nil if the system chooses it
+func localBindAddress(config *KNXConfig, ipv6 bool) (net.IP, error) {
+	if len(config.LocalAddress) > 0 {
+		return net.ParseIP(config.LocalAddress), nil
+	}
+	if len(config.Interface) == 0 {
+		return nil, nil
+	}
+
+	ifi, err := net.InterfaceByName(config.Interface)
+	if err != nil {
+		return nil, fmt.Errorf("knx.interface: %s", err)
+	}
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("knx.interface: %s", err)
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		// link-local IPv6 addresses would require a zone
+		if !ok || (ipNet.IP.To4() == nil) != ipv6 || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+
+		return ipNet.IP, nil
+	}
+
+	family := "IPv4"
+	if ipv6 {
+		family = "IPv6"
+	}
+
+	return nil, fmt.Errorf("knx.interface %s has no %s address", config.Interface, family)
+}
+
+// udpRelay forwards datagrams between a loopback socket and a socket
+// connected to the gateway
+type udpRelay struct {
+	// local is the loopback socket the tunnel connects to
+	local *net.UDPConn
+	// remote is the socket connected to the gateway, replaced by redial
+	remote atomic.Pointer[net.UDPConn]
+	// peer is the address of the tunnel socket, nil until it sent
+	peer atomic.Pointer[net.UDPAddr]
+	// closed is set once Close was called
+	closed atomic.Bool
+
+	// laddr is the address remote is bound to, nil if chosen by the system
+	laddr net.IP
+	// hostname of the gateway re-resolved by connection requests, optional
+	hostname string
+
+	// rewrite replaces the address announced by tunnel requests
+	rewrite bool
+}
+
+// newUDPRelay returns a started *udpRelay forwarding to gateway from laddr.
+// If hostname is set, it is resolved again by every connection request.
+func newUDPRelay(laddr net.IP, gateway *net.UDPAddr, hostname string, rewrite bool) (*udpRelay, error) {
+	r := &udpRelay{
+		laddr:    laddr,
+		hostname: hostname,
+		rewrite:  rewrite,
+	}
+	remote, err := r.dial(gateway)
+	if err != nil {
+		return nil, err
+	}
+	r.remote.Store(remote)
+	r.local, err = net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		remote.Close() // nolint:errcheck
+		return nil, err
+	}
+
+	go r.forwardOut()
+	go r.forwardIn()
+
+	return r, nil
+}
+
+// dial returns a socket connected to gateway bound to laddr
+func (r *udpRelay) dial(gateway *net.UDPAddr) (*net.UDPConn, error) {
+	var bind *net.UDPAddr
+	if r.laddr != nil {
+		bind = &net.UDPAddr{IP: r.laddr}
+	}
+	remote, err := net.DialUDP("udp", bind, gateway)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %s", gateway, err)
+	}
+
+	return remote, nil
+}
+
+// addr implements relay
+func (r *udpRelay) addr() string {
+	return r.local.LocalAddr().String()
+}
+
+// Close implements relay
+func (r *udpRelay) Close() error {
+	r.closed.Store(true)
+	r.local.Close() // nolint:errcheck
+
+	return r.remote.Load().Close()
+}
+
+// forwardOut forwards datagrams of the tunnel to the gateway until closed
+func (r *udpRelay) forwardOut() {
+	buffer := make([]byte, 1024)
+	for {
+		n, peer, err := r.local.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		r.peer.Store(peer)
+
+		packet := buffer[:n]
+		if r.isConnReq(packet) {
+			r.redial()
+		}
+		r.remote.Load().Write(r.hostInfo(packet)) // nolint:errcheck
+	}
+}
+
+// forwardIn forwards datagrams of the gateway to the tunnel until closed
+func (r *udpRelay) forwardIn() {
+	buffer := make([]byte, 1024)
+	for {
+		remote := r.remote.Load()
+		n, err := remote.Read(buffer)
+		if err != nil {
+			// read from the new socket if remote was replaced by redial
+			if !r.closed.Load() && r.remote.Load() != remote {
+				continue
+			}
+			return
+		}
+		peer := r.peer.Load()
+		if peer == nil {
+			continue
+		}
+		r.local.WriteToUDP(buffer[:n], peer) // nolint:errcheck
+	}
+}
+
+// isConnReq returns whether packet is a connection request, which the
+// tunnel sends when connecting and on every reconnect attempt
+func (r *udpRelay) isConnReq(packet []byte) bool {
+	var service knxnet.ServiceID
+	var length uint16
+	if _, err := knxnet.UnpackHeader(packet, &service, &length); err != nil {
+		return false
+	}
+
+	return service == knxnet.ConnReqService
+}
+
+// redial resolves hostname again and replaces remote by a socket connected
+// to the resolved address if it changed. The address family is kept as
+// the bound address and announced host infos depend on it. On errors
+// the current socket is kept.
+func (r *udpRelay) redial() {
+	if len(r.hostname) == 0 {
+		return
+	}
+
+	current := r.remote.Load()
+	raddr := current.RemoteAddr().(*net.UDPAddr)
+	network := "ip6"
+	if raddr.IP.To4() != nil {
+		network = "ip4"
+	}
+	ip, err := net.ResolveIPAddr(network, r.hostname)
+	if err != nil || (ip.IP.Equal(raddr.IP) && ip.Zone == raddr.Zone) {
+		return
+	}
+
+	remote, err := r.dial(&net.UDPAddr{IP: ip.IP, Zone: ip.Zone, Port: raddr.Port})
+	if err != nil {
+		return
+	}
+	// store before closing for forwardIn to continue with remote
+	r.remote.Store(remote)
+	current.Close() // nolint:errcheck
+	if r.closed.Load() {
+		remote.Close() // nolint:errcheck
+	}
+}
+
+// hostInfo replaces the loopback address announced in requests by the
+// address of the socket connected to the gateway. Host infos only carry
+// IPv4, so IPv6 is announced as 0.0.0.0:0 for the gateway to reply to
+// the sender.
+func (r *udpRelay) hostInfo(packet []byte) []byte {
+	var service knxnet.ServiceID
+	var length uint16
+	if _, err := knxnet.UnpackHeader(packet, &service, &length); err != nil {
+		return packet
+	}
+	switch service {
+	case knxnet.DescrReqService:
+		// description requests always announce their address
+	case knxnet.ConnReqService, knxnet.ConnStateReqService, knxnet.DiscReqService:
+		if !r.rewrite {
+			return packet
+		}
+	default:
+		return packet
+	}
+
+	var srv knxnet.Service
+	if _, err := knxnet.Unpack(packet, &srv); err != nil {
+		return packet
+	}
+	hostInfo, err := knxnet.HostInfoFromAddress(r.remote.Load().LocalAddr())
+	if err != nil {
+		hostInfo = knxnet.HostInfo{Protocol: knxnet.UDP4}
+	}
+
+	switch req := srv.(type) {
+	case *knxnet.DescriptionReq:
+		req.HostInfo = hostInfo
+		return knxnet.AllocAndPack(req)
+	case *knxnet.ConnReq:
+		req.Control = hostInfo
+		req.Tunnel = hostInfo
+		return knxnet.AllocAndPack(req)
+	case *knxnet.ConnStateReq:
+		req.Control = hostInfo
+		return knxnet.AllocAndPack(req)
+	case *knxnet.DiscReq:
+		req.Control = hostInfo
+		return knxnet.AllocAndPack(req)
+	}
+
+	return packet
+}
+
+// tcpRelay forwards the first connection accepted on a loopback listener
+// to a connection to the gateway
+type tcpRelay struct {
+	// listener accepts the tunnel connection
+	listener net.Listener
+	// remote is the connection to the gateway
+	remote net.Conn
+
+	// local is the accepted tunnel connection, nil until accepted
+	local net.Conn
+	// closed is set once Close was called
+	closed bool
+	// m_local synchronizes access to local and closed
+	m_local sync.Mutex
+}
+
+// newTCPRelay returns a started *tcpRelay connected to gateway from laddr
+func newTCPRelay(laddr net.IP, gateway string) (*tcpRelay, error) {
+	dialer := net.Dialer{}
+	if laddr != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: laddr}
+	}
+	remote, err := dialer.Dial("tcp", gateway)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		remote.Close() // nolint:errcheck
+		return nil, err
+	}
+
+	r := &tcpRelay{
+		listener: listener,
+		remote:   remote,
+	}
+	go r.forward()
+
+	return r, nil
+}
+
+// addr implements relay
+func (r *tcpRelay) addr() string {
+	return r.listener.Addr().String()
+}
+
+// Close implements relay
+func (r *tcpRelay) Close() error {
+	r.m_local.Lock()
+	defer r.m_local.Unlock()
+
+	r.closed = true
+	if r.local != nil {
+		r.local.Close() // nolint:errcheck
+	}
+	r.listener.Close() // nolint:errcheck
+
+	return r.remote.Close()
+}
+
+// forward accepts the tunnel connection and copies both directions
+// until either connection is closed
+func (r *tcpRelay) forward() {
+	local, err := r.listener.Accept()
+	if err != nil {
+		return
+	}
+	r.listener.Close() // nolint:errcheck
+
+	r.m_local.Lock()
+	if r.closed {
+		r.m_local.Unlock()
+		local.Close() // nolint:errcheck
+		return
+	}
+	r.local = local
+	r.m_local.Unlock()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(r.remote, local) // nolint:errcheck
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, r.remote) // nolint:errcheck
+		done <- struct{}{}
+	}()
+	<-done
+
+	// close both to end the other direction
+	local.Close()    // nolint:errcheck
+	r.remote.Close() // nolint:errcheck
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/rs/zerolog"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// ReloadConfig holds the config reload settings
+type ReloadConfig struct {
+	// Watch whether to reload the config file when it changes,
+	// SIGHUP always triggers a reload
+	Watch bool `mapstructure:"watch" default:"false"`
+}
+
+// Validate validates the ReloadConfig
+func (c *ReloadConfig) Validate() error {
+	return nil
+}
+
+// reloadable holds the state replaced by Reload
+type reloadable struct {
+	// publishFilter restricts events written to the bus
+	publishFilter *publishFilter
+
+	// suppressed stores group address patterns never dispatched to subscribers
+	suppressed []groupAddressPattern
+
+	// tenants stores the configured tenants
+	tenants []*tenant
+
+	// apiKeys stores the configured named API keys
+	apiKeys []*apiKey
+
+	// directory stores the configured group addresses
+	directory map[cemi.GroupAddr]*GroupAddressConfig
+
+	// quota limits Subscribe streams
+	quota QuotaConfig
+}
+
+// reloadable returns the current reloadable state
+func (s *Server) reloadable() *reloadable {
+	s.m_state.RLock()
+	defer s.m_state.RUnlock()
+
+	return s.state
+}
+
+// Reload applies the non-structural settings of config without dropping
+// the tunnel or open streams: log level, publish and suppress filters,
+// tenants, API keys, quota and the group address directory.
+// Changes of any other setting are logged and require a restart.
+func (s *Server) Reload(config *Config) error {
+	if config == nil {
+		return errors.New("missing config")
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("config: %s", err)
+	}
+
+	level, err := zerolog.ParseLevel(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("config: unknown log.level: %s", config.Log.Level)
+	}
+	publishFilter, err := newPublishFilter(&config.RPC.PublishFilter)
+	if err != nil {
+		return fmt.Errorf("config: rpc.publishFilter: %s", err)
+	}
+	suppressed, err := parseGroupAddressPatterns(config.RPC.SuppressFilter.GroupAddresses)
+	if err != nil {
+		return fmt.Errorf("config: rpc.suppressFilter: %s", err)
+	}
+	tenants, err := newTenants(config.RPC.Tenants)
+	if err != nil {
+		return fmt.Errorf("config: rpc.tenants: %s", err)
+	}
+	directory, err := newDirectory(config.KNX.directoryEntries())
+	if err != nil {
+		return fmt.Errorf("config: knx.groupAddresses: %s", err)
+	}
+
+	// secrets are fetched for the references known at startup only
+	for _, ref := range config.secretReferences() {
+		if s.secrets == nil || !slices.Contains(s.secrets.refs, ref) {
+			return fmt.Errorf("config: new secret reference %s requires a restart", ref)
+		}
+	}
+
+	s.m_state.Lock()
+	keepTenantState(s.state.tenants, tenants)
+	s.state = &reloadable{
+		publishFilter: publishFilter,
+		suppressed:    suppressed,
+		tenants:       tenants,
+		apiKeys:       newAPIKeys(config.RPC.Auth.Keys),
+		directory:     directory,
+		quota:         config.RPC.Quota,
+	}
+	s.m_state.Unlock()
+
+	zerolog.SetGlobalLevel(level)
+
+	if changed := restartRequired(s.config, config); len(changed) > 0 {
+		s.log.Warn().
+			Strs("settings", changed).
+			Msg("changed settings require a restart")
+	}
+	s.log.Info().
+		Str("log-level", level.String()).
+		Int("tenants", len(tenants)).
+		Int("keys", len(config.RPC.Auth.Keys)).
+		Int("group-addresses", len(directory)).
+		Msg("config reloaded")
+
+	return nil
+}
+
+// keepTenantState moves the stream counters and publish limiters of
+// previous tenants to the tenants of the same name.
+func keepTenantState(previous, tenants []*tenant) {
+	for _, t := range tenants {
+		for _, prev := range previous {
+			if prev.config.Name != t.config.Name {
+				continue
+			}
+
+			t.subscribers = prev.subscribers
+			if prev.limiter != nil && t.limiter != nil {
+				prev.limiter.SetLimit(t.limiter.Limit())
+				prev.limiter.SetBurst(t.limiter.Burst())
+				t.limiter = prev.limiter
+			}
+		}
+	}
+}
+
+// restartRequired returns the names of settings which differ between
+// previous and config but are not applied by Reload.
+func restartRequired(previous, config *Config) []string {
+	// ignore reloadable settings by copying them over
+	prev := *previous
+	next := *config
+	next.Log.Level = prev.Log.Level
+	next.KNX.GroupAddresses = prev.KNX.GroupAddresses
+	next.RPC.Auth.Keys = prev.RPC.Auth.Keys
+	next.RPC.PublishFilter = prev.RPC.PublishFilter
+	next.RPC.SuppressFilter = prev.RPC.SuppressFilter
+	next.RPC.Tenants = prev.RPC.Tenants
+	next.RPC.Quota = prev.RPC.Quota
+	next.Client = prev.Client
+
+	ret := changedFields("", reflect.ValueOf(prev), reflect.ValueOf(next))
+	slices.Sort(ret)
+
+	return ret
+}
+
+// changedFields returns the mapstructure names of the fields differing
+// between the structs a and b, descending into the rpc section.
+func changedFields(prefix string, a, b reflect.Value) []string {
+	ret := []string{}
+	for i := 0; i < a.NumField(); i++ {
+		name := prefix + a.Type().Field(i).Tag.Get("mapstructure")
+		fa, fb := a.Field(i), b.Field(i)
+		if name == "rpc" {
+			ret = append(ret, changedFields(name+".", fa, fb)...)
+			continue
+		}
+		if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+var (
+	ErrReplayDisabled = errors.New("replaying recent messages is disabled")
+)
+
+// ReplayConfig holds the config of replaying recent events on subscribing
+type ReplayConfig struct {
+	// Enabled whether to buffer recent events per group address
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// MaxEvents is the amount of recent events kept per group address
+	MaxEvents int `mapstructure:"maxEvents" default:"10"`
+
+	// MaxAge is the age after which events are no longer replayed
+	MaxAge time.Duration `mapstructure:"maxAge" default:"1h"`
+}
+
+// Validate validates the ReplayConfig
+func (c *ReplayConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxEvents <= 0 {
+		return fmt.Errorf("invalid rpc.replay.maxEvents")
+	}
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("invalid rpc.replay.maxAge")
+	}
+
+	return nil
+}
+
+// replayBuffer keeps the recent events of every group address
+// to replay them to new subscriptions
+type replayBuffer struct {
+	maxEvents int
+	maxAge    time.Duration
+
+	// rings keeps the last events by group address
+	rings map[cemi.GroupAddr]*eventRing
+	// m_rings synchronizes access to rings
+	m_rings sync.Mutex
+}
+
+// newReplayBuffer returns a *replayBuffer from config, nil if disabled
+func newReplayBuffer(config *ReplayConfig) *replayBuffer {
+	if !config.Enabled {
+		return nil
+	}
+
+	return &replayBuffer{
+		maxEvents: config.MaxEvents,
+		maxAge:    config.MaxAge,
+		rings:     map[cemi.GroupAddr]*eventRing{},
+	}
+}
+
+// record stores entry replacing the oldest entry of its group address once full
+func (b *replayBuffer) record(entry resumeEntry) {
+	if b == nil {
+		return
+	}
+
+	b.m_rings.Lock()
+	defer b.m_rings.Unlock()
+
+	ring, ok := b.rings[entry.event.Destination]
+	if !ok {
+		ring = newEventRing(b.maxEvents)
+		b.rings[entry.event.Destination] = ring
+	}
+	ring.add(entry)
+}
+
+// last returns the entries of each group address of addresses (any if
+// empty) received within, from oldest to newest. A zero within returns
+// the entries up to rpc.replay.maxAge.
+func (b *replayBuffer) last(addresses []cemi.GroupAddr, within time.Duration) [][]resumeEntry {
+	b.m_rings.Lock()
+	defer b.m_rings.Unlock()
+
+	if within <= 0 || within > b.maxAge {
+		within = b.maxAge
+	}
+	oldest := time.Now().Add(-within)
+
+	rings := []*eventRing{}
+	if len(addresses) > 0 {
+		seen := map[cemi.GroupAddr]bool{}
+		for _, ga := range addresses {
+			if ring, ok := b.rings[ga]; ok && !seen[ga] {
+				rings = append(rings, ring)
+			}
+			seen[ga] = true
+		}
+	} else {
+		for _, ring := range b.rings {
+			rings = append(rings, ring)
+		}
+	}
+
+	ret := [][]resumeEntry{}
+	for _, ring := range rings {
+		entries := []resumeEntry{}
+		for _, entry := range ring.ordered() {
+			if !entry.received.Before(oldest) {
+				entries = append(entries, entry)
+			}
+		}
+		ret = append(ret, entries)
+	}
+
+	return ret
+}
+
+// parseReplayLast returns the count and within of last or error
+func parseReplayLast(last *v1.ReplayLast) (int, time.Duration, error) {
+	if last.Count == 0 && len(last.Within) == 0 {
+		return 0, 0, fieldErrorf("replay_last", "replay_last requires count or within")
+	}
+
+	var within time.Duration
+	if len(last.Within) > 0 {
+		var err error
+		within, err = time.ParseDuration(last.Within)
+		if err != nil {
+			return 0, 0, fieldErrorf("replay_last.within", "parsing 'within': %v", err)
+		}
+		if within <= 0 {
+			return 0, 0, fieldErrorf("replay_last.within", "'within' must be positive")
+		}
+	}
+
+	return int(last.Count), within, nil
+}
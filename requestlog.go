@@ -0,0 +1,205 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// redactedFields are the payload fields replaced if rpc.requestLog.redact is set
+var redactedFields = map[string]bool{
+	"data":  true,
+	"value": true,
+}
+
+// RequestLogConfig holds the RPC request log config
+type RequestLogConfig struct {
+	// Enabled logs every RPC with procedure, peer, identity,
+	// group addresses, code and latency
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// Payload additionally logs the request message as JSON
+	Payload bool `mapstructure:"payload" default:"false"`
+
+	// Redact replaces data and values of logged payloads
+	Redact bool `mapstructure:"redact" default:"true"`
+}
+
+// Validate validates the RequestLogConfig
+func (c *RequestLogConfig) Validate() error {
+	return nil
+}
+
+// requestLogInterceptor is a connect.Interceptor logging every RPC,
+// unlike rpc.webserver.logRequests it knows the identity and message.
+type requestLogInterceptor struct {
+	config  *RequestLogConfig
+	log     *zerolog.Logger
+	proxies trustedProxies
+}
+
+// logCall logs a finished call to procedure with its request message msg
+func (i *requestLogInterceptor) logCall(
+	ctx context.Context,
+	procedure, peer string,
+	msg any,
+	started time.Time,
+	err error,
+) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+
+	ev := i.log.Info().
+		Str("procedure", procedure).
+		Str("peer", peer).
+		Str("identity", identityFromContext(ctx)).
+		Strs("group-addresses", requestGroupAddresses(msg)).
+		Str("code", code).
+		Dur("latency", time.Since(started))
+	if i.config.Payload {
+		if payload := i.payload(msg); payload != nil {
+			ev = ev.RawJSON("request", payload)
+		}
+	}
+	ev.Msg("rpc")
+}
+
+// payload returns msg as JSON, redacted if configured, or nil
+func (i *requestLogInterceptor) payload(msg any) []byte {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil || !i.config.Redact {
+		return b
+	}
+
+	var obj any
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil
+	}
+	b, err = json.Marshal(redact(obj))
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// redact replaces redactedFields within the decoded JSON obj
+func redact(obj any) any {
+	switch obj := obj.(type) {
+	case map[string]any:
+		for k, v := range obj {
+			if redactedFields[k] {
+				obj[k] = "[redacted]"
+				continue
+			}
+			obj[k] = redact(v)
+		}
+	case []any:
+		for idx, v := range obj {
+			obj[idx] = redact(v)
+		}
+	}
+
+	return obj
+}
+
+// requestGroupAddresses returns the group addresses targeted by msg
+func requestGroupAddresses(msg any) []string {
+	switch m := msg.(type) {
+	case *v1.SubscribeUnaryRequest:
+		return m.GetSubscribeRequest().GetGroupAddresses()
+	case *v1.TransactionRequest:
+		ret := []string{}
+		for _, w := range m.GetWrites() {
+			ret = append(ret, w.GetGroupAddress())
+		}
+		return ret
+	case *v1.ScanRequest:
+		if len(m.GetTo()) > 0 {
+			return []string{m.GetFrom(), m.GetTo()}
+		}
+		return []string{m.GetFrom()}
+	case interface{ GetGroupAddresses() []string }:
+		return m.GetGroupAddresses()
+	case interface{ GetGroupAddress() string }:
+		return []string{m.GetGroupAddress()}
+	}
+
+	return []string{}
+}
+
+// WrapUnary implements [connect.Interceptor]
+func (i *requestLogInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		started := time.Now()
+		res, err := next(ctx, req)
+		if !req.Spec().IsClient {
+			i.logCall(ctx, req.Spec().Procedure,
+				i.proxies.peerAddr(req.Peer().Addr, req.Header()), req.Any(), started, err)
+		}
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements [connect.Interceptor] with a no-op.
+func (i *requestLogInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements [connect.Interceptor]
+func (i *requestLogInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		started := time.Now()
+		rconn := &receivedConn{StreamingHandlerConn: conn}
+		err := next(ctx, rconn)
+		i.logCall(ctx, conn.Spec().Procedure,
+			i.proxies.peerAddr(conn.Peer().Addr, conn.RequestHeader()), rconn.msg, started, err)
+		return err
+	}
+}
+
+// receivedConn stores the first message received by a StreamingHandlerConn
+type receivedConn struct {
+	connect.StreamingHandlerConn
+
+	// msg is the first received message, nil if none
+	msg any
+}
+
+// Receive implements connect.StreamingHandlerConn
+func (c *receivedConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil && c.msg == nil {
+		c.msg = msg
+	}
+
+	return err
+}
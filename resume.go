@@ -0,0 +1,191 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vapourismo/knx-go/knx"
+)
+
+var (
+	ErrResumeDisabled = errors.New("resuming subscriptions is disabled")
+	ErrResumeExpired  = errors.New("resume token expired, messages were lost")
+)
+
+// ResumeConfig holds the config of resumable subscriptions
+type ResumeConfig struct {
+	// Enabled whether to buffer recent events and add resume tokens to messages
+	Enabled bool `mapstructure:"enabled" default:"false"`
+
+	// MaxEvents is the amount of recent events kept for resuming
+	MaxEvents int `mapstructure:"maxEvents" default:"1000"`
+}
+
+// Validate validates the ResumeConfig
+func (c *ResumeConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MaxEvents <= 0 {
+		return fmt.Errorf("invalid rpc.resume.maxEvents")
+	}
+
+	return nil
+}
+
+// resumeEntry is a buffered event
+type resumeEntry struct {
+	event    *knx.GroupEvent
+	sequence uint64
+	received time.Time
+}
+
+// eventRing keeps the last entries in order of their sequence
+type eventRing struct {
+	// entries is a ring of the last events, next is the oldest once full
+	entries []resumeEntry
+	next    int
+	full    bool
+}
+
+// newEventRing returns a fresh *eventRing keeping size entries
+func newEventRing(size int) *eventRing {
+	return &eventRing{
+		entries: make([]resumeEntry, size),
+	}
+}
+
+// add stores entry replacing the oldest entry once full
+func (r *eventRing) add(entry resumeEntry) {
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// ordered returns a copy of the entries from oldest to newest
+func (r *eventRing) ordered() []resumeEntry {
+	ret := make([]resumeEntry, 0, len(r.entries))
+	if r.full {
+		ret = append(ret, r.entries[r.next:]...)
+	}
+
+	return append(ret, r.entries[:r.next]...)
+}
+
+// resumeBuffer keeps the recent events of all group addresses in order of
+// their sequence to replay them to resumed subscriptions
+type resumeBuffer struct {
+	// instance identifies the process, tokens of other processes are invalid
+	instance string
+
+	// entries keeps the last events
+	entries *eventRing
+	// m_entries synchronizes access to entries
+	m_entries sync.Mutex
+}
+
+// newResumeBuffer returns a *resumeBuffer from config, nil if disabled
+func newResumeBuffer(config *ResumeConfig) (*resumeBuffer, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	instance, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &resumeBuffer{
+		instance: instance,
+		entries:  newEventRing(config.MaxEvents),
+	}, nil
+}
+
+// record stores entry replacing the oldest entry once full
+func (b *resumeBuffer) record(entry resumeEntry) {
+	if b == nil {
+		return
+	}
+
+	b.m_entries.Lock()
+	defer b.m_entries.Unlock()
+
+	b.entries.add(entry)
+}
+
+// token returns the resume token of sequence, empty if disabled
+func (b *resumeBuffer) token(sequence uint64) string {
+	if b == nil {
+		return ""
+	}
+
+	return b.instance + "-" + strconv.FormatUint(sequence, 10)
+}
+
+// parseToken returns the sequence of token or error
+func (b *resumeBuffer) parseToken(token string) (uint64, error) {
+	if b == nil {
+		return 0, ErrResumeDisabled
+	}
+
+	instance, sequence, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, fieldErrorf("resume_from", "invalid resume token: %s", token)
+	}
+	seq, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return 0, fieldErrorf("resume_from", "invalid resume token: %s", token)
+	}
+	if instance != b.instance {
+		// knxrpc restarted since the token was issued
+		return 0, ErrResumeExpired
+	}
+
+	return seq, nil
+}
+
+// since returns the buffered entries after sequence in order or
+// ErrResumeExpired if entries after sequence were already replaced
+func (b *resumeBuffer) since(sequence uint64) ([]resumeEntry, error) {
+	b.m_entries.Lock()
+	defer b.m_entries.Unlock()
+
+	ordered := b.entries.ordered()
+
+	// sequences are contiguous as every dispatched event is recorded
+	if len(ordered) > 0 && ordered[0].sequence > sequence+1 {
+		return nil, ErrResumeExpired
+	}
+
+	ret := []resumeEntry{}
+	for _, entry := range ordered {
+		if entry.sequence > sequence {
+			ret = append(ret, entry)
+		}
+	}
+
+	return ret, nil
+}
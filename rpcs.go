@@ -19,11 +19,12 @@ package knxrpc
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"connectrpc.com/connect"
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
 )
 
 // Publish implements knx.groupaddressservice.v1.Publish
@@ -31,15 +32,53 @@ func (s *Server) Publish(
 	ctx context.Context,
 	req *connect.Request[v1.PublishRequest],
 ) (*connect.Response[v1.PublishResponse], error) {
-	res := &v1.PublishResponse{}
-
 	event, err := fromV1PublishRequest(req.Msg)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+	if len(req.Msg.Value) > 0 {
+		event.Data, err = s.encodePublishValue(event, req.Msg)
+		if err != nil {
+			return nil, newConnectError(connect.CodeInvalidArgument, err)
+		}
+	}
+	at, err := parsePublishSchedule(req.Msg)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	// check tenant restrictions
+	t := tenantFromContext(ctx)
+	if err := t.checkGroupAddresses(event.Destination); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	// retried requests using the same idempotency key are published once
+	res, err := s.deduplicate(ctx, t.name(), idempotencyKey(req),
+		func() (*v1.PublishResponse, error) {
+			if !at.IsZero() {
+				return s.schedulePublish(t, req.Msg, event, at)
+			}
+			return s.publish(t, event)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// publish writes event to the bus on behalf of tenant t
+func (s *Server) publish(t *tenant, event *knx.GroupEvent) (*v1.PublishResponse, error) {
+	if err := t.allowPublish(); err != nil {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
 	}
 
 	// write to bus
-	err = s.tunnel.Send(*event)
+	err := s.sendGroupEvent(event)
+	if errors.Is(err, ErrPublishDenied) {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -51,7 +90,7 @@ func (s *Server) Publish(
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(res), nil
+	return &v1.PublishResponse{}, nil
 }
 
 // Subscribe implements knx.groupaddressservice.v1.Subscribe
@@ -60,19 +99,33 @@ func (s *Server) Subscribe(
 	req *connect.Request[v1.SubscribeRequest],
 	stream *connect.ServerStream[v1.SubscribeResponse],
 ) error {
-	// parse group addresses
-	addresses, err := parseGroupAddresses(req.Msg.GroupAddresses)
+	sub, err := newSubscriber(ctx,
+		s.trustedProxies.peerAddr(req.Peer().Addr, req.Header()), req.Msg)
 	if err != nil {
-		return connect.NewError(connect.CodeInvalidArgument, err)
+		return connect.NewError(connect.CodeInternal, err)
 	}
+	sub.stream = stream
+	sub.fields, err = newFieldMask(&v1.SubscribeResponse{}, req.Msg.Fields)
+	if err != nil {
+		return newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	return s.serveSubscriber(ctx, sub)
+}
 
-	if len(addresses) > 0 {
-		// register group addresses to subscribe
-		s.registerSubscriber(addresses, req.Msg, stream)
-	} else {
-		// no filtering on group_addresses -> sniffer
-		s.registerSniffer(req.Msg, stream)
+// serveSubscriber subscribes the stream of sub and blocks until ctx or
+// the server is done. The statistics are sent as trailers afterwards.
+func (s *Server) serveSubscriber(ctx context.Context, sub *subscriber) error {
+	// the statistics are final once unsubscribed
+	defer sub.setTrailers()
+
+	// the stream must not be sent to once we return, so
+	// unsubscribe on any return
+	unsubscribe, err := s.subscribe(sub)
+	if err != nil {
+		return err
 	}
+	defer unsubscribe()
 
 	// block until any ctx is done
 	select {
@@ -81,14 +134,6 @@ func (s *Server) Subscribe(
 		return connect.NewError(connect.CodeAborted, s.ctx.Err())
 	}
 
-	if len(addresses) > 0 {
-		// remove us from subscribed group addresses
-		s.unregisterSubscriber(addresses, stream)
-	} else {
-		// no filtering on group_addresses -> sniffer
-		s.unregisterSniffer(stream)
-	}
-
 	return nil
 }
 
@@ -97,53 +142,241 @@ func (s *Server) SubscribeUnary(
 	ctx context.Context,
 	req *connect.Request[v1.SubscribeUnaryRequest],
 ) (*connect.Response[v1.SubscribeUnaryResponse], error) {
-	// construct internal client
-	client, err := NewClient(s.config.Client)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("constructing client: %v", err))
-	}
-
-	// input validation
+	// input validation, wait stays negative without timeout
+	wait := time.Duration(-1)
 	if req.Msg.For != "" {
 		dur, err := time.ParseDuration(req.Msg.For)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("parsing 'for': %v", err))
+			return nil, newConnectError(connect.CodeInvalidArgument, fieldErrorf("for", "parsing 'for': %v", err))
 		}
+		if dur <= 0 {
+			return nil, newConnectError(connect.CodeInvalidArgument, fieldErrorf("for", "'for' must be positive"))
+		}
+		wait = dur
+	}
+	maxMessages := int(req.Msg.MaxMessages)
+	if req.Msg.For == "" && maxMessages == 0 {
+		// return the first message
+		maxMessages = 1
+	}
+	// return before the request deadline so the caller receives the results
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline) - unaryDeadlineMargin
+		if wait < 0 || remaining < wait {
+			wait = max(remaining, 0)
+		}
+	}
 
-		// init timer
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, dur)
-		defer cancel()
+	subReq := req.Msg.SubscribeRequest
+	if subReq == nil {
+		subReq = &v1.SubscribeRequest{}
+	}
+	sub, err := newSubscriber(ctx,
+		s.trustedProxies.peerAddr(req.Peer().Addr, req.Header()), subReq)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	sub.messages = make(chan *v1.SubscribeResponse, unaryBufferSize)
+	sub.fields, err = newFieldMask(&v1.SubscribeResponse{}, subReq.Fields)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	unsubscribe, err := s.subscribe(sub)
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+
+	var timeout <-chan time.Time
+	if wait >= 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
 	}
 
 	// resp collects all messages
 	resp := connect.NewResponse(&v1.SubscribeUnaryResponse{
 		Messages: []*v1.SubscribeResponse{},
 	})
+	for {
+		select {
+		case msg := <-sub.messages:
+			resp.Msg.Messages = append(resp.Msg.Messages, msg)
+			if maxMessages > 0 && len(resp.Msg.Messages) >= maxMessages {
+				resp.Msg.Reason = v1.SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_MAX_MESSAGES
+				return resp, nil
+			}
+
+		case <-timeout:
+			resp.Msg.Reason = v1.SubscribeUnaryReason_SUBSCRIBE_UNARY_REASON_TIMEOUT
+			return resp, nil
+
+		// the caller is gone
+		case <-ctx.Done():
+			return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+
+		case <-s.ctx.Done():
+			return nil, connect.NewError(connect.CodeAborted, s.ctx.Err())
+		}
+	}
+}
 
-	// open stream
-	stream, err := client.Subscribe(ctx, connect.NewRequest(req.Msg.SubscribeRequest))
+// Scan implements knx.groupaddressservice.v1.Scan
+func (s *Server) Scan(
+	ctx context.Context,
+	req *connect.Request[v1.ScanRequest],
+) (*connect.Response[v1.ScanResponse], error) {
+	opts, err := s.parseScanRequest(req.Msg)
 	if err != nil {
-		return nil, err
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
 	}
-	defer stream.Close() // nolint:errcheck
 
-	// start receiver loop
-	for stream.Receive() {
-		msg := stream.Msg()
-		resp.Msg.Messages = append(resp.Msg.Messages, msg)
+	// check tenant restrictions for the whole range
+	t := tenantFromContext(ctx)
+	for ga := uint32(opts.from); ga <= uint32(opts.to); ga++ {
+		if err := t.checkGroupAddresses(cemi.GroupAddr(ga)); err != nil {
+			return nil, newConnectError(connect.CodePermissionDenied, err)
+		}
+	}
 
-		// if user provided no `for`, quit after first message
-		if req.Msg.For == "" {
-			break
+	res, err := s.scan(ctx, opts)
+	if errors.Is(err, ErrPublishDenied) {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// ListScheduled implements knx.groupaddressservice.v1.ListScheduled
+func (s *Server) ListScheduled(
+	ctx context.Context,
+	req *connect.Request[v1.ListScheduledRequest],
+) (*connect.Response[v1.ListScheduledResponse], error) {
+	return connect.NewResponse(&v1.ListScheduledResponse{
+		Scheduled: s.listScheduled(tenantFromContext(ctx)),
+	}), nil
+}
+
+// CancelScheduled implements knx.groupaddressservice.v1.CancelScheduled
+func (s *Server) CancelScheduled(
+	ctx context.Context,
+	req *connect.Request[v1.CancelScheduledRequest],
+) (*connect.Response[v1.CancelScheduledResponse], error) {
+	err := s.cancelScheduled(tenantFromContext(ctx), req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	return connect.NewResponse(&v1.CancelScheduledResponse{}), nil
+}
+
+// Transaction implements knx.groupaddressservice.v1.Transaction
+func (s *Server) Transaction(
+	ctx context.Context,
+	req *connect.Request[v1.TransactionRequest],
+) (*connect.Response[v1.TransactionResponse], error) {
+	writes, expires, err := parseTransactionRequest(req.Msg)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	// check tenant restrictions
+	t := tenantFromContext(ctx)
+	for _, w := range writes {
+		if err := t.checkGroupAddresses(w.Destination); err != nil {
+			return nil, newConnectError(connect.CodePermissionDenied, err)
 		}
 	}
-	if connectErr := new(connect.Error); errors.As(err, &connectErr) &&
-		!errors.Is(connectErr, context.Canceled) &&
-		!errors.Is(connectErr, context.DeadlineExceeded) {
-		return nil, connect.NewError(connect.CodeInternal,
-			fmt.Errorf("knxrpc stream closed: %s", stream.Err()))
+
+	res, err := s.beginTransaction(ctx, t, writes, expires)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// CommitTransaction implements knx.groupaddressservice.v1.CommitTransaction
+func (s *Server) CommitTransaction(
+	ctx context.Context,
+	req *connect.Request[v1.CommitTransactionRequest],
+) (*connect.Response[v1.CommitTransactionResponse], error) {
+	err := s.commitTransaction(tenantFromContext(ctx), req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	return connect.NewResponse(&v1.CommitTransactionResponse{}), nil
+}
+
+// RevertTransaction implements knx.groupaddressservice.v1.RevertTransaction
+func (s *Server) RevertTransaction(
+	ctx context.Context,
+	req *connect.Request[v1.RevertTransactionRequest],
+) (*connect.Response[v1.RevertTransactionResponse], error) {
+	err := s.revertTransaction(tenantFromContext(ctx), req.Msg.Id)
+	if errors.Is(err, ErrTransactionNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v1.RevertTransactionResponse{}), nil
+}
+
+// Aggregate implements knx.groupaddressservice.v1.Aggregate
+func (s *Server) Aggregate(
+	ctx context.Context,
+	req *connect.Request[v1.AggregateRequest],
+) (*connect.Response[v1.AggregateResponse], error) {
+	opts, err := s.parseAggregateRequest(req.Msg)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	// check tenant restrictions
+	if err := tenantFromContext(ctx).checkGroupAddresses(opts.ga); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	res, err := s.aggregate(opts)
+	if errors.Is(err, ErrHistoryDisabled) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// ListGroupAddresses implements knx.groupaddressservice.v1.ListGroupAddresses
+func (s *Server) ListGroupAddresses(
+	ctx context.Context,
+	req *connect.Request[v1.ListGroupAddressesRequest],
+) (*connect.Response[v1.ListGroupAddressesResponse], error) {
+	return connect.NewResponse(&v1.ListGroupAddressesResponse{
+		GroupAddresses: s.listGroupAddresses(tenantFromContext(ctx)),
+		Dpts:           listDatapointTypes(),
+	}), nil
+}
+
+// ListSubscribers implements knx.groupaddressservice.v1.ListSubscribers
+func (s *Server) ListSubscribers(
+	ctx context.Context,
+	req *connect.Request[v1.ListSubscribersRequest],
+) (*connect.Response[v1.ListSubscribersResponse], error) {
+	// streams of all tenants are listed
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
 	}
 
-	return resp, nil
+	return connect.NewResponse(&v1.ListSubscribersResponse{
+		Subscribers: s.listSubscribers(),
+	}), nil
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"maps"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v2 "github.com/choopm/knxrpc/knx/groupaddress/v2"
+	v2Connect "github.com/choopm/knxrpc/knx/groupaddress/v2/v2connect"
+)
+
+// groupAddressServiceV2 implements knx.groupaddress.v2.GroupAddressService
+// on top of the v1 implementation of Server
+type groupAddressServiceV2 struct {
+	v2Connect.UnimplementedGroupAddressServiceHandler
+
+	s *Server
+}
+
+// Publish implements knx.groupaddressservice.v2.Publish
+func (h *groupAddressServiceV2) Publish(
+	ctx context.Context,
+	req *connect.Request[v2.PublishRequest],
+) (*connect.Response[v2.PublishResponse], error) {
+	msg, err := fromV2PublishRequest(req.Msg)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	// headers carry the Idempotency-Key
+	v1Req := connect.NewRequest(msg)
+	maps.Copy(v1Req.Header(), req.Header())
+	res, err := h.s.Publish(ctx, v1Req)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&v2.PublishResponse{
+		ScheduledId: res.Msg.ScheduledId,
+	}), nil
+}
+
+// Subscribe implements knx.groupaddressservice.v2.Subscribe
+func (h *groupAddressServiceV2) Subscribe(
+	ctx context.Context,
+	req *connect.Request[v2.SubscribeRequest],
+	stream *connect.ServerStream[v2.SubscribeResponse],
+) error {
+	sub, err := newSubscriber(ctx,
+		h.s.trustedProxies.peerAddr(req.Peer().Addr, req.Header()),
+		&v1.SubscribeRequest{
+			GroupAddresses: req.Msg.GroupAddresses,
+			Event:          v1.Event(req.Msg.Event),
+			ResumeFrom:     req.Msg.ResumeFrom,
+			ReplayLast:     fromV2ReplayLast(req.Msg.ReplayLast),
+		})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	sub.streamV2 = stream
+	sub.fields, err = newFieldMask(&v2.SubscribeResponse{}, req.Msg.Fields)
+	if err != nil {
+		return newConnectError(connect.CodeInvalidArgument, err)
+	}
+
+	return h.s.serveSubscriber(ctx, sub)
+}
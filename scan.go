@@ -0,0 +1,171 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/dpt"
+)
+
+// defaultScanInterval is the default pause between read requests of a scan
+const defaultScanInterval = 100 * time.Millisecond
+
+// scanOptions holds the parsed parameters of a scan
+type scanOptions struct {
+	from     cemi.GroupAddr
+	to       cemi.GroupAddr
+	interval time.Duration
+	timeout  time.Duration
+	dpt      string
+}
+
+// parseScanRequest returns scanOptions from req or error
+func (s *Server) parseScanRequest(req *v1.ScanRequest) (*scanOptions, error) {
+	opts := &scanOptions{
+		interval: defaultScanInterval,
+		timeout:  s.config.KNX.Timeout,
+		dpt:      req.Dpt,
+	}
+
+	var err error
+	opts.from, err = cemi.NewGroupAddrString(req.From)
+	if err != nil {
+		return nil, fieldErrorf("from", "parse from: %s", err)
+	}
+	opts.to = opts.from
+	if len(req.To) > 0 {
+		opts.to, err = cemi.NewGroupAddrString(req.To)
+		if err != nil {
+			return nil, fieldErrorf("to", "parse to: %s", err)
+		}
+	}
+	if opts.to < opts.from {
+		return nil, fieldErrorf("to", "to %s is lower than from %s", opts.to, opts.from)
+	}
+
+	if len(req.Interval) > 0 {
+		opts.interval, err = time.ParseDuration(req.Interval)
+		if err != nil {
+			return nil, fieldErrorf("interval", "parsing 'interval': %v", err)
+		}
+	}
+	if len(req.Timeout) > 0 {
+		opts.timeout, err = time.ParseDuration(req.Timeout)
+		if err != nil {
+			return nil, fieldErrorf("timeout", "parsing 'timeout': %v", err)
+		}
+	}
+	if len(opts.dpt) > 0 {
+		if _, ok := dpt.Produce(opts.dpt); !ok {
+			return nil, fieldErrorf("dpt", "unsupported dpt: %s", opts.dpt)
+		}
+	}
+
+	return opts, nil
+}
+
+// scan sends read requests to all group addresses within opts
+// and collects the first response of each group address.
+func (s *Server) scan(ctx context.Context, opts *scanOptions) (*v1.ScanResponse, error) {
+	events, unregister := s.registerEventListener()
+	defer unregister()
+
+	res := &v1.ScanResponse{
+		Results: []*v1.ScanResult{},
+	}
+	answered := map[cemi.GroupAddr]bool{}
+
+	// collect records the first response of a group address within range
+	collect := func(event *knx.GroupEvent) {
+		if event.Command != knx.GroupResponse ||
+			event.Destination < opts.from || event.Destination > opts.to ||
+			answered[event.Destination] {
+			return
+		}
+		answered[event.Destination] = true
+
+		res.Results = append(res.Results, toV1ScanResult(event, opts.dpt))
+	}
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	// send paced read requests
+	for ga := uint32(opts.from); ga <= uint32(opts.to); {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event := <-events:
+			collect(event)
+		case <-ticker.C:
+			err := s.sendGroupEvent(&knx.GroupEvent{
+				Command:     knx.GroupRead,
+				Destination: cemi.GroupAddr(ga),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", cemi.GroupAddr(ga), err)
+			}
+			res.Scanned++
+			ga++
+		}
+	}
+
+	// wait for late responses
+	timeout := time.After(opts.timeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event := <-events:
+			collect(event)
+		case <-timeout:
+			return res, nil
+		}
+	}
+}
+
+// toV1ScanResult returns the v1.ScanResult of event, checking its data
+// against datapoint type dptName if given.
+func toV1ScanResult(event *knx.GroupEvent, dptName string) *v1.ScanResult {
+	ret := &v1.ScanResult{
+		GroupAddress:    event.Destination.String(),
+		PhysicalAddress: event.Source.String(),
+		Data:            event.Data,
+	}
+
+	if len(dptName) == 0 {
+		return ret
+	}
+
+	dp, ok := dpt.Produce(dptName)
+	if !ok {
+		return ret
+	}
+	if err := dp.Unpack(event.Data); err != nil {
+		return ret
+	}
+	ret.DptValid = true
+	ret.Value = dp.String()
+
+	return ret
+}
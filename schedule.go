@@ -0,0 +1,211 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+)
+
+// maxScheduledPublishes limits the amount of pending scheduled publishes
+const maxScheduledPublishes = 1024
+
+var (
+	ErrScheduledNotFound = errors.New("scheduled publish not found")
+	ErrScheduledTooMany  = errors.New("too many scheduled publishes")
+)
+
+// scheduledPublish stores a publish which is executed later
+type scheduledPublish struct {
+	id string
+	at time.Time
+
+	// req is the original request
+	req *v1.PublishRequest
+
+	// event is the parsed req
+	event *knx.GroupEvent
+
+	// tenant which scheduled it, nil if unrestricted
+	tenant *tenant
+
+	// timer executes the publish
+	timer *time.Timer
+}
+
+// parsePublishSchedule returns the time req should be published at,
+// zero if it has to be published immediately.
+func parsePublishSchedule(req *v1.PublishRequest) (time.Time, error) {
+	if len(req.Delay) > 0 && len(req.At) > 0 {
+		return time.Time{}, fieldErrorf("at", "delay and at are mutually exclusive")
+	}
+
+	if len(req.Delay) > 0 {
+		delay, err := time.ParseDuration(req.Delay)
+		if err != nil {
+			return time.Time{}, fieldErrorf("delay", "parsing 'delay': %v", err)
+		}
+		if delay <= 0 {
+			return time.Time{}, fieldErrorf("delay", "invalid delay %s", delay)
+		}
+
+		return time.Now().Add(delay), nil
+	}
+
+	if len(req.At) > 0 {
+		at, err := time.Parse(time.RFC3339, req.At)
+		if err != nil {
+			return time.Time{}, fieldErrorf("at", "parsing 'at': %v", err)
+		}
+		if !at.After(time.Now()) {
+			return time.Time{}, fieldErrorf("at", "at %s is not in the future", req.At)
+		}
+
+		return at, nil
+	}
+
+	return time.Time{}, nil
+}
+
+// schedulePublish schedules event to be published at on behalf of tenant t
+func (s *Server) schedulePublish(
+	t *tenant,
+	req *v1.PublishRequest,
+	event *knx.GroupEvent,
+	at time.Time,
+) (*v1.PublishResponse, error) {
+	// fail early instead of when the timer fires
+	if err := s.reloadable().publishFilter.check(event); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	id, err := newRandomID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.m_scheduled.Lock()
+	defer s.m_scheduled.Unlock()
+
+	if len(s.scheduled) >= maxScheduledPublishes {
+		return nil, connect.NewError(connect.CodeResourceExhausted, ErrScheduledTooMany)
+	}
+
+	sp := &scheduledPublish{
+		id:     id,
+		at:     at,
+		req:    req,
+		event:  event,
+		tenant: t,
+	}
+	sp.timer = time.AfterFunc(time.Until(at), func() {
+		s.runScheduled(sp)
+	})
+	s.scheduled[id] = sp
+
+	s.log.Debug().
+		Str("id", id).
+		Str("group-address", event.Destination.String()).
+		Time("at", at).
+		Msg("scheduled publish")
+
+	return &v1.PublishResponse{
+		ScheduledId: id,
+	}, nil
+}
+
+// runScheduled publishes sp and removes it from s.scheduled
+func (s *Server) runScheduled(sp *scheduledPublish) {
+	s.m_scheduled.Lock()
+	_, ok := s.scheduled[sp.id]
+	delete(s.scheduled, sp.id)
+	s.m_scheduled.Unlock()
+
+	if !ok {
+		// cancelled meanwhile
+		return
+	}
+	if s.ctx == nil || s.ctx.Err() != nil {
+		// server is not running
+		return
+	}
+
+	_, err := s.publish(sp.tenant, sp.event)
+	if err != nil {
+		s.log.Error().
+			Err(err).
+			Str("id", sp.id).
+			Str("group-address", sp.event.Destination.String()).
+			Msg("unable to publish scheduled event")
+		return
+	}
+
+	s.log.Debug().
+		Str("id", sp.id).
+		Str("group-address", sp.event.Destination.String()).
+		Msg("published scheduled event")
+}
+
+// listScheduled returns the scheduled publishes visible to tenant t ordered by time
+func (s *Server) listScheduled(t *tenant) []*v1.ScheduledPublish {
+	s.m_scheduled.Lock()
+	defer s.m_scheduled.Unlock()
+
+	list := []*scheduledPublish{}
+	for _, sp := range s.scheduled {
+		if t != nil && sp.tenant != t {
+			continue
+		}
+		list = append(list, sp)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].at.Before(list[j].at)
+	})
+
+	ret := []*v1.ScheduledPublish{}
+	for _, sp := range list {
+		ret = append(ret, &v1.ScheduledPublish{
+			Id:             sp.id,
+			At:             sp.at.Format(time.RFC3339),
+			PublishRequest: sp.req,
+		})
+	}
+
+	return ret
+}
+
+// cancelScheduled cancels the scheduled publish id visible to tenant t
+// or returns ErrScheduledNotFound.
+func (s *Server) cancelScheduled(t *tenant, id string) error {
+	s.m_scheduled.Lock()
+	defer s.m_scheduled.Unlock()
+
+	sp, ok := s.scheduled[id]
+	if !ok || (t != nil && sp.tenant != t) {
+		return ErrScheduledNotFound
+	}
+
+	sp.timer.Stop()
+	delete(s.scheduled, id)
+
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2idPrefix prefixes argon2id hashes in PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$<base64 salt>$<base64 hash>
+const argon2idPrefix = "$argon2id$"
+
+// argon2id parameters used by HashSecret
+const (
+	argon2idMemory  = 64 * 1024
+	argon2idTime    = 3
+	argon2idThreads = 2
+	argon2idSaltLen = 16
+	argon2idKeyLen  = 32
+)
+
+// verifiedSecrets caches the hashed secret successfully verified by the
+// sha256 of a value, as verifying hashes is slow by design. A verified value
+// is assumed to not match any other hashed secret.
+var verifiedSecrets sync.Map
+
+// HashSecret returns a hash of secret to be used in the config instead of
+// the plaintext secret. algorithm is oneof: bcrypt|argon2id
+func HashSecret(secret, algorithm string) (string, error) {
+	switch algorithm {
+	case "bcrypt":
+		hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	case "argon2id":
+		salt := make([]byte, argon2idSaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		hash := argon2.IDKey([]byte(secret), salt,
+			argon2idTime, argon2idMemory, argon2idThreads, argon2idKeyLen)
+		return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+			argon2idMemory, argon2idTime, argon2idThreads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(hash)), nil
+	}
+
+	return "", fmt.Errorf("unsupported algorithm: %s", algorithm)
+}
+
+// isHashedSecret returns whether secret is a bcrypt or argon2id hash
+func isHashedSecret(secret string) bool {
+	return strings.HasPrefix(secret, "$2a$") ||
+		strings.HasPrefix(secret, "$2b$") ||
+		strings.HasPrefix(secret, "$2y$") ||
+		strings.HasPrefix(secret, argon2idPrefix)
+}
+
+// validateSecret returns an error if secret looks hashed but is malformed
+func validateSecret(secret string) error {
+	switch {
+	case strings.HasPrefix(secret, argon2idPrefix):
+		_, _, _, err := parseArgon2id(secret)
+		return err
+	case isHashedSecret(secret):
+		_, err := bcrypt.Cost([]byte(secret))
+		return err
+	}
+
+	return nil
+}
+
+// compareSecret returns whether the user provided value val matches secret.
+// secret may be plaintext or a bcrypt or argon2id hash, empty never matches.
+func compareSecret(val, secret string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	if !isHashedSecret(secret) {
+		return subtle.ConstantTimeCompare([]byte(val), []byte(secret)) == 1
+	}
+
+	sum := sha256.Sum256([]byte(val))
+	if verified, ok := verifiedSecrets.Load(sum); ok {
+		return verified.(string) == secret
+	}
+
+	var ok bool
+	if strings.HasPrefix(secret, argon2idPrefix) {
+		ok = compareArgon2id(val, secret)
+	} else {
+		ok = bcrypt.CompareHashAndPassword([]byte(secret), []byte(val)) == nil
+	}
+	if ok {
+		verifiedSecrets.Store(sum, secret)
+	}
+
+	return ok
+}
+
+// parseArgon2id returns the params, salt and hash of an argon2id PHC string
+func parseArgon2id(secret string) (params [3]uint32, salt, hash []byte, err error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, hash
+	parts := strings.Split(secret, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id version: %s", err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version: %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&params[0], &params[1], &params[2]); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id params: %s", err)
+	}
+	if params[1] == 0 || params[2] == 0 || params[2] > 255 {
+		return params, nil, nil, errors.New("invalid argon2id params")
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %s", err)
+	}
+	hash, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash: %s", err)
+	}
+	if len(hash) == 0 {
+		return params, nil, nil, errors.New("empty argon2id hash")
+	}
+
+	return params, salt, hash, nil
+}
+
+// compareArgon2id returns whether val matches the argon2id PHC string secret
+func compareArgon2id(val, secret string) bool {
+	params, salt, hash, err := parseArgon2id(secret)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(val), salt,
+		params[1], params[0], uint8(params[2]), uint32(len(hash)))
+
+	return subtle.ConstantTimeCompare(hash, other) == 1
+}
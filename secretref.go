@@ -0,0 +1,162 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// secretEnvRef matches ${ENV} references in secrets
+var secretEnvRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// secretRef describes a secret field of a config struct and the
+// field referencing a file to read the secret from.
+type secretRef struct {
+	// field is the mapstructure name of the secret field
+	field string
+	// fileField is the mapstructure name of the file reference field
+	fileField string
+	// list whether field is a list of secrets, the file holds one per line
+	list bool
+}
+
+// secretRefs lists the secret fields by config struct
+var secretRefs = map[reflect.Type]secretRef{
+	reflect.TypeOf(AuthConfig{}):          {field: "secretKey", fileField: "secretKeyFile"},
+	reflect.TypeOf(APIKeyConfig{}):        {field: "secretKey", fileField: "secretKeyFile"},
+	reflect.TypeOf(TenantConfig{}):        {field: "secretKeys", fileField: "secretKeysFile", list: true},
+	reflect.TypeOf(BasicAuthUserConfig{}): {field: "password", fileField: "passwordFile"},
+	reflect.TypeOf(HMACConfig{}):          {field: "secretKey", fileField: "secretKeyFile"},
+	reflect.TypeOf(VaultConfig{}):         {field: "token", fileField: "tokenFile"},
+	reflect.TypeOf(AWSSecretsConfig{}):    {field: "secretAccessKey", fileField: "secretAccessKeyFile"},
+}
+
+// DecodeHook implements configfx.CustomDecoder.
+// It resolves ${ENV} references in secrets and reads secrets from files.
+func (c *Config) DecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		ref, ok := secretRefs[to]
+		if !ok {
+			return data, nil
+		}
+		m, ok := data.(map[string]any)
+		if !ok {
+			return data, nil
+		}
+
+		return ref.resolve(m)
+	}
+}
+
+// resolve returns a copy of m having the secrets resolved or error
+func (r secretRef) resolve(m map[string]any) (map[string]any, error) {
+	ret := map[string]any{}
+	fieldKey, fileKey := r.field, ""
+	for k, v := range m {
+		ret[k] = v
+		// viper lowercases keys
+		switch {
+		case strings.EqualFold(k, r.field):
+			fieldKey = k
+		case strings.EqualFold(k, r.fileField):
+			fileKey = k
+		}
+	}
+
+	// expand references in the given secrets
+	switch v := ret[fieldKey].(type) {
+	case string:
+		s, err := expandSecretEnv(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", r.field, err)
+		}
+		ret[fieldKey] = s
+	case []any:
+		list := []any{}
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				list = append(list, item)
+				continue
+			}
+			s, err := expandSecretEnv(s)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", r.field, err)
+			}
+			list = append(list, s)
+		}
+		ret[fieldKey] = list
+	}
+
+	// read the file reference
+	path, _ := ret[fileKey].(string)
+	if len(fileKey) == 0 || len(path) == 0 {
+		return ret, nil
+	}
+	path, err := expandSecretEnv(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", r.fileField, err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", r.fileField, err)
+	}
+
+	if !r.list {
+		if s, _ := ret[fieldKey].(string); len(s) > 0 {
+			return nil, fmt.Errorf("%s and %s are mutually exclusive", r.field, r.fileField)
+		}
+		ret[fieldKey] = strings.TrimSpace(string(b))
+		return ret, nil
+	}
+
+	// one secret per line, appended to the given secrets
+	list, _ := ret[fieldKey].([]any)
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	ret[fieldKey] = list
+
+	return ret, nil
+}
+
+// expandSecretEnv returns s with ${ENV} references replaced by their values
+// or an error if any referenced variable is unset. Other $ signs as used
+// by hashed secrets are kept.
+func expandSecretEnv(s string) (string, error) {
+	var err error
+	ret := secretEnvRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := secretEnvRef.FindStringSubmatch(ref)[1]
+		val, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %s is not set", name)
+		}
+		return val
+	})
+
+	return ret, err
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// secret reference prefixes by provider
+const (
+	secretsProviderVault = "vault"
+	secretsProviderAWS   = "aws"
+)
+
+// SecretsConfig holds the config of an external secrets provider.
+// Secrets of rpc.auth, rpc.tenants and the webserver auth sections may
+// reference it using <provider>:<path>#<key>, e.g.: vault:knxrpc/auth#token
+type SecretsConfig struct {
+	// Provider to fetch referenced secrets from, optional
+	// oneof: vault|aws
+	Provider string `mapstructure:"provider"`
+
+	// Refresh is the interval to refetch secrets in
+	Refresh time.Duration `mapstructure:"refresh" default:"5m"`
+
+	// Timeout for fetching a secret
+	Timeout time.Duration `mapstructure:"timeout" default:"10s"`
+
+	// Vault config to use if Provider is vault
+	Vault VaultConfig `mapstructure:"vault"`
+
+	// AWS config to use if Provider is aws
+	AWS AWSSecretsConfig `mapstructure:"aws"`
+}
+
+// Validate validates the SecretsConfig
+func (c *SecretsConfig) Validate() error {
+	switch c.Provider {
+	case "":
+		return nil
+	case secretsProviderVault:
+		if err := c.Vault.Validate(); err != nil {
+			return err
+		}
+	case secretsProviderAWS:
+		if err := c.AWS.Validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported rpc.secrets.provider: %s", c.Provider)
+	}
+
+	if c.Refresh <= 0 {
+		return fmt.Errorf("invalid rpc.secrets.refresh")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("invalid rpc.secrets.timeout")
+	}
+
+	return nil
+}
+
+// secretsProvider fetches secrets from an external secret manager
+type secretsProvider interface {
+	// fetch returns the value of key stored at path or error
+	fetch(ctx context.Context, path, key string) (string, error)
+}
+
+// newSecretsProvider returns the secretsProvider of config, nil if none
+func newSecretsProvider(config *SecretsConfig) secretsProvider {
+	switch config.Provider {
+	case secretsProviderVault:
+		return newVaultProvider(&config.Vault, config.Timeout)
+	case secretsProviderAWS:
+		return newAWSSecretsProvider(&config.AWS, config.Timeout)
+	}
+
+	return nil
+}
+
+// parseSecretRef returns the provider, path and key of a secret reference,
+// ok is false if secret is no reference.
+func parseSecretRef(secret string) (provider, path, key string, ok bool) {
+	provider, ref, found := strings.Cut(secret, ":")
+	if !found || (provider != secretsProviderVault && provider != secretsProviderAWS) {
+		return "", "", "", false
+	}
+	path, key, _ = strings.Cut(ref, "#")
+
+	return provider, path, key, len(path) > 0
+}
+
+// secretReferences returns all secrets of config referencing a provider
+func (c *Config) secretReferences() []string {
+	secrets := []string{
+		c.RPC.Auth.SecretKey,
+		c.RPC.Auth.HMAC.SecretKey,
+		c.RPC.Webserver.Metrics.Auth.SecretKey,
+		c.RPC.Webserver.History.Auth.SecretKey,
+	}
+	for _, users := range [][]BasicAuthUserConfig{
+		c.RPC.Webserver.Metrics.BasicAuth.Users,
+		c.RPC.Webserver.Swagger.BasicAuth.Users,
+	} {
+		for _, u := range users {
+			secrets = append(secrets, u.Password)
+		}
+	}
+	for _, key := range c.RPC.Auth.Keys {
+		secrets = append(secrets, key.SecretKey)
+	}
+	for _, t := range c.RPC.Tenants {
+		secrets = append(secrets, t.SecretKeys...)
+	}
+
+	ret := []string{}
+	for _, secret := range secrets {
+		if _, _, _, ok := parseSecretRef(secret); ok {
+			ret = append(ret, secret)
+		}
+	}
+
+	return ret
+}
+
+// secretStore stores the values of fetched secret references
+type secretStore struct {
+	provider secretsProvider
+	refs     []string
+
+	// values stores fetched values by reference
+	values map[string]string
+	// m_values synchronizes access to values
+	m_values sync.RWMutex
+}
+
+// get returns the value of secret if it is a reference or secret itself
+func (st *secretStore) get(secret string) string {
+	if st == nil {
+		return secret
+	}
+	if _, _, _, ok := parseSecretRef(secret); !ok {
+		return secret
+	}
+
+	st.m_values.RLock()
+	defer st.m_values.RUnlock()
+
+	// an unresolved reference never matches as compareSecret rejects empty secrets
+	return st.values[secret]
+}
+
+// refresh fetches all references, keeping previous values on errors
+func (st *secretStore) refresh(ctx context.Context) error {
+	values := map[string]string{}
+	var errs []string
+	for _, ref := range st.refs {
+		_, path, key, _ := parseSecretRef(ref)
+		val, err := st.provider.fetch(ctx, path, key)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", ref, err))
+			continue
+		}
+		values[ref] = val
+	}
+
+	st.m_values.Lock()
+	for ref, val := range values {
+		st.values[ref] = val
+	}
+	st.m_values.Unlock()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("fetching secrets: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// secret returns the value of a configured secret resolving references
+func (s *Server) secret(secret string) string {
+	return s.secrets.get(secret)
+}
+
+// secretsRefresher refetches referenced secrets periodically until ctx is done
+func (s *Server) secretsRefresher(ctx context.Context) error {
+	if s.secrets == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.config.RPC.Secrets.Refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := s.secrets.refresh(ctx); err != nil {
+			s.log.Warn().
+				Err(err).
+				Msg("refreshing secrets failed, keeping previous values")
+			continue
+		}
+
+		s.log.Debug().
+			Int("secrets", len(s.secrets.refs)).
+			Msg("secrets refreshed")
+	}
+}
@@ -24,10 +24,11 @@ import (
 	"net/http"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	deviceV1Connect "github.com/choopm/knxrpc/knx/device/v1/v1connect"
 	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
@@ -42,6 +43,7 @@ import (
 type Server struct {
 	http.Handler
 	v1Connect.UnimplementedGroupAddressServiceHandler
+	deviceV1Connect.UnimplementedDeviceServiceHandler
 
 	// holds Config during runtime
 	config *Config
@@ -54,7 +56,13 @@ type Server struct {
 	cancel context.CancelFunc
 
 	// tunnel stores the connected KNX tunnel
-	tunnel knx.GroupTunnel
+	tunnel *knx.Tunnel
+	// relay forwards tunnel to the gateway if required
+	relay relay
+	// connected is the time tunnel was connected
+	connected time.Time
+	// tunnelAddress learns the individual address of tunnel
+	tunnelAddress *tunnelAddress
 
 	// e stores the echo instance if any
 	e *echo.Echo
@@ -65,17 +73,100 @@ type Server struct {
 	// meterProvider stores the OpenTelemetry MeterProvider
 	meterProvider *metric.MeterProvider
 
+	// state stores the settings replaced by Reload
+	state *reloadable
+	// m_state synchronizes access to state
+	m_state sync.RWMutex
+
+	// staticKey is the identity of requests using rpc.auth.secretKey
+	staticKey *apiKey
+
+	// ldap authenticates directory users, nil if disabled
+	ldap *ldapAuthenticator
+
+	// hmac verifies signed publish requests, nil if disabled
+	hmac *hmacVerifier
+
+	// secrets stores fetched secret references, nil if unused
+	secrets *secretStore
+
+	// history stores recent events, nil if disabled
+	history *history
+
+	// repeats detects repeated telegrams received from the bus
+	repeats *repeatDetector
+
+	// bridge forwards telegrams to a second gateway, nil if disabled
+	bridge *bridge
+
+	// sequence numbers the dispatched events
+	sequence atomic.Uint64
+
+	// resume buffers recent events for resumed subscriptions, nil if disabled
+	resume *resumeBuffer
+	// replay buffers recent events per group address, nil if disabled
+	replay *replayBuffer
+
+	// trustedProxies are the reverse proxies allowed to forward peers
+	trustedProxies trustedProxies
+
+	// lastEvents stores the last value carrying event of each group address
+	lastEvents map[cemi.GroupAddr]lastEvent
+	// m_lastEvents synchronizes access to lastEvents
+	m_lastEvents sync.Mutex
+
 	// --- RPC and open streams related down below ---
 
-	// subscribers stores all group addresses to connected streams
+	// subscribers stores all group addresses to connected streams,
+	// its slices are replaced on changes and may be read without the lock
 	subscribers map[cemi.GroupAddr][]*subscriber
 	// m_subscribers synchronizes access to subscribers
-	m_subscribers sync.Mutex
+	m_subscribers sync.RWMutex
 
 	// sniffers stores subscribers which receive all group addresses (no filtering)
 	sniffers []*subscriber
 	// m_sniffers synchronizes access to sniffers
-	m_sniffers sync.Mutex
+	m_sniffers sync.RWMutex
+
+	// streams counts all open Subscribe streams
+	streams int
+	// identityStreams counts open Subscribe streams per identity
+	identityStreams map[string]int
+	// m_streams synchronizes access to streams and identityStreams
+	m_streams sync.Mutex
+
+	// idempotency stores results of publishes by identity and idempotency key
+	idempotency map[string]*idempotencyEntry
+	// m_idempotency synchronizes access to idempotency
+	m_idempotency sync.Mutex
+
+	// scheduled stores pending scheduled publishes by id
+	scheduled map[string]*scheduledPublish
+	// m_scheduled synchronizes access to scheduled
+	m_scheduled sync.Mutex
+
+	// transactions stores open transactions by id
+	transactions map[string]*transaction
+	// m_transactions synchronizes access to transactions
+	m_transactions sync.Mutex
+
+	// ackConsumers stores the consumers of SubscribeAcked by identity and name
+	ackConsumers map[string]*ackConsumer
+	// m_ackConsumers synchronizes access to ackConsumers
+	m_ackConsumers sync.Mutex
+
+	// eventListeners stores channels of internal consumers receiving all group events
+	eventListeners []chan *knx.GroupEvent
+	// m_eventListeners synchronizes access to eventListeners
+	m_eventListeners sync.RWMutex
+
+	// deviceListeners stores channels receiving management frames
+	deviceListeners []chan *cemi.LData
+	// m_deviceListeners synchronizes access to deviceListeners
+	m_deviceListeners sync.RWMutex
+
+	// deviceLock serializes device management connections
+	deviceLock chan struct{}
 }
 
 // New returns a new *KNXConnect or error
@@ -94,11 +185,72 @@ func New(config *Config, logger *zerolog.Logger) (*Server, error) {
 		logger = &log.Logger
 	}
 
+	publishFilter, err := newPublishFilter(&config.RPC.PublishFilter)
+	if err != nil {
+		return nil, fmt.Errorf("config: rpc.publishFilter: %s", err)
+	}
+	suppressed, err := parseGroupAddressPatterns(config.RPC.SuppressFilter.GroupAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("config: rpc.suppressFilter: %s", err)
+	}
+	tenants, err := newTenants(config.RPC.Tenants)
+	if err != nil {
+		return nil, fmt.Errorf("config: rpc.tenants: %s", err)
+	}
+	directory, err := newDirectory(config.KNX.directoryEntries())
+	if err != nil {
+		return nil, fmt.Errorf("config: knx.groupAddresses: %s", err)
+	}
+	trustedProxies, err := parseTrustedProxies(config.RPC.Webserver.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("config: rpc.webserver.%s", err)
+	}
+	resume, err := newResumeBuffer(&config.RPC.Resume)
+	if err != nil {
+		return nil, fmt.Errorf("config: rpc.resume: %s", err)
+	}
+
 	s := &Server{
 		config:      config,
 		log:         logger,
 		subscribers: map[cemi.GroupAddr][]*subscriber{},
 		sniffers:    []*subscriber{},
+		deviceLock:  make(chan struct{}, 1),
+
+		trustedProxies: trustedProxies,
+		tunnelAddress:  newTunnelAddress(),
+		resume:         resume,
+		replay:         newReplayBuffer(&config.RPC.Replay),
+
+		identityStreams: map[string]int{},
+		idempotency:     map[string]*idempotencyEntry{},
+		scheduled:       map[string]*scheduledPublish{},
+		transactions:    map[string]*transaction{},
+		ackConsumers:    map[string]*ackConsumer{},
+
+		state: &reloadable{
+			publishFilter: publishFilter,
+			suppressed:    suppressed,
+			tenants:       tenants,
+			apiKeys:       newAPIKeys(config.RPC.Auth.Keys),
+			directory:     directory,
+			quota:         config.RPC.Quota,
+		},
+		staticKey: &apiKey{
+			config: &APIKeyConfig{Name: identityStatic, Role: RoleAdmin},
+		},
+		ldap:       newLDAPAuthenticator(&config.RPC.Auth.LDAP),
+		hmac:       newHMACVerifier(&config.RPC.Auth.HMAC),
+		history:    newHistory(&config.RPC.History),
+		lastEvents: map[cemi.GroupAddr]lastEvent{},
+	}
+
+	if refs := config.secretReferences(); len(refs) > 0 {
+		s.secrets = &secretStore{
+			provider: newSecretsProvider(&config.RPC.Secrets),
+			refs:     refs,
+			values:   map[string]string{},
+		}
 	}
 
 	return s, nil
@@ -120,6 +272,16 @@ func (s *Server) Start(ctx context.Context) error {
 		return err
 	}
 
+	// fetch referenced secrets before accepting requests
+	if s.secrets != nil {
+		if err := s.secrets.refresh(ctx); err != nil {
+			return err
+		}
+		g.Go(func() error {
+			return s.secretsRefresher(ctx)
+		})
+	}
+
 	s.log.Trace().
 		Msg("knx knxrpc connecting")
 
@@ -127,12 +289,42 @@ func (s *Server) Start(ctx context.Context) error {
 	if err := s.connectTunnel(); err != nil {
 		return err
 	}
+	if s.relay != nil {
+		defer s.relay.Close() // nolint:errcheck
+	}
 	defer s.tunnel.Close()
 	// bind closer to ctx
 	context.AfterFunc(ctx, func() {
 		s.tunnel.Close() // nolint:errcheck
 	})
 
+	// connect the bridged gateway
+	if s.bridge != nil {
+		if err := s.connectBridge(); err != nil {
+			return err
+		}
+		if s.bridge.relay != nil {
+			defer s.bridge.relay.Close() // nolint:errcheck
+		}
+		defer s.bridge.tunnel.Close()
+		context.AfterFunc(ctx, func() {
+			s.bridge.tunnel.Close() // nolint:errcheck
+		})
+		g.Go(func() error {
+			return s.bridgeReader(ctx)
+		})
+		g.Go(func() error {
+			return s.bridgeSender(ctx)
+		})
+	}
+
+	// bind the webserver before notifying systemd
+	if s.config.RPC.Webserver.Enabled {
+		if err := s.listen(); err != nil {
+			return err
+		}
+	}
+
 	// start webserver
 	g.Go(func() error {
 		// webserver is not enabled
@@ -141,8 +333,15 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 
 		// shutdown hook, register before Start()
+		drained := make(chan struct{})
 		context.AfterFunc(ctx, func() {
-			err := s.e.Shutdown(ctx)
+			defer close(drained)
+
+			// ctx is done already, drain in-flight requests
+			shutdownCtx, cancel := context.WithTimeout(context.Background(),
+				s.config.RPC.Webserver.ShutdownTimeout)
+			defer cancel()
+			err := s.e.Shutdown(shutdownCtx)
 			if err != nil {
 				s.e.Close() // nolint:errcheck
 			}
@@ -161,9 +360,10 @@ func (s *Server) Start(ctx context.Context) error {
 				Str("hostport", s.e.ListenerAddr().String())
 			if s.config.RPC.Webserver.Swagger.Enabled {
 				hostport := s.e.ListenerAddr().String()
-				if strings.Contains(hostport, "[::]") ||
-					strings.Contains(hostport, "0.0.0.0") {
-					hostport = fmt.Sprintf("localhost:%d", s.config.Client.Port)
+				if host, port, err := net.SplitHostPort(hostport); err == nil &&
+					net.ParseIP(host).IsUnspecified() {
+					// the knxrpc client section is optional, use the bound port
+					hostport = net.JoinHostPort("localhost", port)
 				}
 				ev = ev.Str("swagger", fmt.Sprintf("http://%s%s/",
 					hostport,
@@ -182,6 +382,7 @@ func (s *Server) Start(ctx context.Context) error {
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return err
 		}
+		<-drained
 
 		return nil
 	})
@@ -191,9 +392,25 @@ func (s *Server) Start(ctx context.Context) error {
 		return s.busMessageReader(ctx)
 	})
 
+	// compute virtual group addresses
+	if len(s.config.KNX.VirtualGroupAddresses) > 0 {
+		g.Go(func() error {
+			return s.virtualUpdater(ctx)
+		})
+	}
+
 	s.log.Trace().
 		Msg("knxrpc started")
 
+	if err := sdNotify("READY=1"); err != nil {
+		s.log.Warn().
+			Err(err).
+			Msg("unable to notify systemd")
+	}
+	context.AfterFunc(ctx, func() {
+		sdNotify("STOPPING=1") // nolint:errcheck
+	})
+
 	// block for all to finish
 	err := g.Wait()
 	if err != nil {
@@ -26,7 +26,9 @@ import (
 	"connectrpc.com/authn"
 	"connectrpc.com/connect"
 	"connectrpc.com/otelconnect"
+	deviceV1Connect "github.com/choopm/knxrpc/knx/device/v1/v1connect"
 	v1Connect "github.com/choopm/knxrpc/knx/groupaddress/v1/v1connect"
+	v2Connect "github.com/choopm/knxrpc/knx/groupaddress/v2/v2connect"
 	"github.com/choopm/knxrpc/web"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -47,6 +49,14 @@ func (s *Server) setup() error {
 		return err
 	}
 
+	if err := s.setupRepeatDetector(); err != nil {
+		return err
+	}
+
+	if err := s.setupBridge(); err != nil {
+		return err
+	}
+
 	if err := s.setupRPCHandler(); err != nil {
 		return err
 	}
@@ -85,7 +95,7 @@ func (s *Server) setupOpenTelemetry() (err error) {
 
 // setupRPCHandler initializes s.Handler
 func (s *Server) setupRPCHandler() error {
-	opts := []connect.HandlerOption{}
+	opts := s.config.RPC.Compression.handlerOptions()
 
 	// otel metrics interceptor
 	if s.config.RPC.Webserver.Metrics.Enabled {
@@ -99,9 +109,27 @@ func (s *Server) setupRPCHandler() error {
 		opts = append(opts, connect.WithInterceptors(otelInterceptor))
 	}
 
+	// records calls by the authenticated identity
+	identityInterceptor, err := s.newIdentityInterceptor()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, connect.WithInterceptors(identityInterceptor))
+
+	// logs every call including its identity and group addresses
+	if s.config.RPC.RequestLog.Enabled {
+		opts = append(opts, connect.WithInterceptors(&requestLogInterceptor{
+			config:  &s.config.RPC.RequestLog,
+			log:     s.log,
+			proxies: s.trustedProxies,
+		}))
+	}
+
 	// register RPCs at ServeMux
 	mux := http.NewServeMux()
 	mux.Handle(v1Connect.NewGroupAddressServiceHandler(s, opts...))
+	mux.Handle(v2Connect.NewGroupAddressServiceHandler(&groupAddressServiceV2{s: s}, opts...))
+	mux.Handle(deviceV1Connect.NewDeviceServiceHandler(s, opts...))
 	s.Handler = mux
 
 	// early return if no authentication is required
@@ -126,8 +154,18 @@ func (s *Server) setupWebserver() error {
 	s.e = echo.New()
 	s.e.HideBanner = true
 	s.e.HidePort = true
+
+	// bidi streams require HTTP/2, also serve it without TLS
+	s.e.Server.Protocols = &http.Protocols{}
+	s.e.Server.Protocols.SetHTTP1(true)
+	s.e.Server.Protocols.SetUnencryptedHTTP2(true)
 	s.e.Use(middleware.Recover())
 
+	// log the forwarded client of trusted proxies
+	if len(s.trustedProxies) > 0 {
+		s.e.IPExtractor = echo.ExtractIPFromXFFHeader(s.trustedProxies.echoTrustOptions()...)
+	}
+
 	if s.config.RPC.Webserver.LogRequests {
 		s.e.Logger = lecho.From(*s.log)
 		s.e.Use(middleware.RequestID())
@@ -141,27 +179,57 @@ func (s *Server) setupWebserver() error {
 
 	// bind metrics
 	if s.config.RPC.Webserver.Metrics.Enabled {
+		middlewares := s.webAuthMiddlewares(
+			&s.config.RPC.Webserver.Metrics.Auth,
+			func(auth string, c echo.Context) (bool, error) {
+				err := s.authenticateStaticSecretKey(auth)
+				if err != nil {
+					return false, err
+				}
+
+				return true, nil
+			},
+			&s.config.RPC.Webserver.Metrics.BasicAuth,
+		)
+		middlewares = append(middlewares, echo.WrapMiddleware(func(next http.Handler) http.Handler {
+			return promhttp.Handler()
+		}))
+		s.e.Group(s.config.RPC.Webserver.Metrics.Path, middlewares...)
+	}
+
+	// bind history download
+	if s.config.RPC.Webserver.History.Enabled {
 		middlewares := []echo.MiddlewareFunc{}
-		if s.config.RPC.Webserver.Metrics.Auth.Enabled {
+		if s.config.RPC.Compression.Gzip {
+			middlewares = append(middlewares, middleware.GzipWithConfig(
+				middleware.GzipConfig{MinLength: s.config.RPC.Compression.MinBytes},
+			))
+		}
+		if s.config.RPC.Webserver.History.Auth.Enabled {
+			auth := s.config.RPC.Webserver.History.Auth
 			middlewares = append(middlewares, middleware.KeyAuthWithConfig(
 				middleware.KeyAuthConfig{
-					KeyLookup:  "header:" + s.config.RPC.Webserver.Metrics.Auth.Header,
-					AuthScheme: s.config.RPC.Webserver.Metrics.Auth.Scheme,
-					Validator: func(auth string, c echo.Context) (bool, error) {
-						err := s.authenticateStaticSecretKey(auth)
-						if err != nil {
-							return false, err
-						}
-
-						return true, nil
+					KeyLookup:  "header:" + auth.Header,
+					AuthScheme: auth.Scheme,
+					Validator: func(key string, c echo.Context) (bool, error) {
+						return compareSecret(key, s.secret(auth.SecretKey)), nil
 					},
 				},
 			))
 		}
-		middlewares = append(middlewares, echo.WrapMiddleware(func(next http.Handler) http.Handler {
-			return promhttp.Handler()
-		}))
-		s.e.Group(s.config.RPC.Webserver.Metrics.Path, middlewares...)
+		s.e.GET(s.config.RPC.Webserver.History.Path, s.historyDownloadHandler, middlewares...)
+	}
+
+	// bind openapi spec
+	if s.config.RPC.Webserver.OpenAPI.Enabled {
+		s.e.GET(s.config.RPC.Webserver.OpenAPI.Path, s.swaggerSpecHandler)
+	}
+
+	// bind web ui
+	if s.config.RPC.Webserver.UI.Enabled {
+		if err := s.setupUI(); err != nil {
+			return err
+		}
 	}
 
 	// early return if swagger is disabled
@@ -182,9 +250,16 @@ func (s *Server) setupWebserver() error {
 		return err
 	}
 
+	// swagger has no header auth as browsers cannot send it
+	middlewares := s.webAuthMiddlewares(&AuthConfig{}, nil,
+		&s.config.RPC.Webserver.Swagger.BasicAuth)
+
+	// bind swagger spec adjusted to this server
+	s.e.GET(swagPath+"/all.swagger.json", s.swaggerSpecHandler, middlewares...)
+
 	// bind swagger fs fileserver
 	swagHandler := http.FileServer(http.FS(swagFS))
-	s.e.Group(swagPath).Use(echo.WrapMiddleware(func(next http.Handler) http.Handler {
+	s.e.Group(swagPath, middlewares...).Use(echo.WrapMiddleware(func(next http.Handler) http.Handler {
 		return http.StripPrefix(swagPath, swagHandler)
 	}))
 
@@ -202,3 +277,47 @@ func (s *Server) setupWebserver() error {
 
 	return nil
 }
+
+// setupUI binds the embedded web UI and its config.json or error
+func (s *Server) setupUI() error {
+	// build ui path
+	uiPath, err := url.JoinPath("/", s.config.RPC.Webserver.UI.Path)
+	if err != nil {
+		return fmt.Errorf("unable to build ui.path: %s", err)
+	}
+	uiPath = strings.TrimSuffix(uiPath, "/")
+
+	// the ui reads the auth header and scheme from config.json
+	swagPath := ""
+	if s.config.RPC.Webserver.Swagger.Enabled {
+		swagPath, err = url.JoinPath("/", s.config.RPC.Webserver.Swagger.Path)
+		if err != nil {
+			return fmt.Errorf("unable to build swagger.path: %s", err)
+		}
+		swagPath = strings.TrimSuffix(swagPath, "/")
+	}
+	s.e.GET(uiPath+"/config.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]any{
+			"auth": map[string]any{
+				"enabled": s.config.RPC.Auth.Enabled,
+				"header":  s.config.RPC.Auth.Header,
+				"scheme":  s.config.RPC.Auth.Scheme,
+			},
+			"swaggerPath": swagPath,
+		})
+	})
+
+	// get the subdirectory content
+	uiFS, err := fs.Sub(web.UIFS, "ui")
+	if err != nil {
+		return err
+	}
+
+	// bind ui fs fileserver
+	uiHandler := http.FileServer(http.FS(uiFS))
+	s.e.Group(uiPath).Use(echo.WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.StripPrefix(uiPath, uiHandler)
+	}))
+
+	return nil
+}
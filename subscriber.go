@@ -17,15 +17,378 @@ limitations under the License.
 package knxrpc
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"connectrpc.com/connect"
 	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	v2 "github.com/choopm/knxrpc/knx/groupaddress/v2"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+const (
+	// unaryBufferSize is the number of messages buffered for SubscribeUnary
+	unaryBufferSize = 64
+
+	// unaryDeadlineMargin ends SubscribeUnary before the request deadline
+	unaryDeadlineMargin = 100 * time.Millisecond
 )
 
 // subscriber stores the details of subscribers
 type subscriber struct {
+	// id identifies the stream in ListSubscribers
+	id string
+
+	// identity opened the stream
+	identity string
+
+	// peer is the remote address of the stream
+	peer string
+
+	// started is the time the stream was opened
+	started time.Time
+
 	// req is the initial request which started the stream
 	req *v1.SubscribeRequest
 
-	// stream is the connected stream
+	// stream is the connected stream, nil for SubscribeUnary and v2
 	stream *connect.ServerStream[v1.SubscribeResponse]
+
+	// streamV2 is the connected v2 stream, nil for v1
+	streamV2 *connect.ServerStream[v2.SubscribeResponse]
+
+	// messages receives the responses of SubscribeUnary, nil for streams
+	messages chan *v1.SubscribeResponse
+
+	// consumer retains the responses of SubscribeAcked, nil for streams
+	consumer *ackConsumer
+
+	// fields projects the responses, nil to send all fields
+	fields *fieldMask
+
+	// addresses subscribed to, empty for sniffers
+	addresses []cemi.GroupAddr
+
+	// replayed is the sequence of the last event replayed on resuming,
+	// older events must not be sent again
+	replayed uint64
+
+	// tenant is the authenticated tenant, nil if unrestricted
+	tenant *tenant
+
+	// closed is set once unregistered, stream must not be used afterwards
+	closed bool
+	// m_stream serializes sending to and closing of stream
+	m_stream sync.Mutex
+
+	// delivered, filtered and dropped count messages by their outcome
+	delivered atomic.Uint64
+	filtered  atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// newSubscriber returns a fresh *subscriber of the caller of ctx or error,
+// either stream, streamV2, messages or consumer has to be set before registering it.
+func newSubscriber(
+	ctx context.Context,
+	peer string,
+	req *v1.SubscribeRequest,
+) (*subscriber, error) {
+	id, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscriber{
+		id:       id,
+		identity: identityFromContext(ctx),
+		peer:     peer,
+		started:  time.Now(),
+		req:      req,
+		tenant:   tenantFromContext(ctx),
+	}, nil
+}
+
+// send sends the response of lazy to the stream or messages unless it
+// has been closed or replayed. It is called without holding the subscribers lock.
+func (sub *subscriber) send(lazy *lazyResponse) error {
+	sub.m_stream.Lock()
+	defer sub.m_stream.Unlock()
+
+	if sub.closed || lazy.sequence <= sub.replayed {
+		return nil
+	}
+
+	return sub.deliver(lazy)
+}
+
+// deliver sends the response of lazy, the caller holds m_stream
+func (sub *subscriber) deliver(lazy *lazyResponse) error {
+	var err error
+	switch {
+	case sub.messages != nil:
+		select {
+		case sub.messages <- sub.response(lazy):
+		default:
+			err = errors.New("messages are not received fast enough")
+		}
+	case sub.consumer != nil:
+		err = sub.consumer.enqueue(sub.response(lazy))
+	case sub.streamV2 != nil:
+		err = sub.streamV2.Send(sub.responseV2(lazy))
+	default:
+		err = sub.stream.Send(sub.response(lazy))
+	}
+	if err != nil {
+		sub.dropped.Add(1)
+		return err
+	}
+	sub.delivered.Add(1)
+
+	return nil
+}
+
+// response returns the v1 response of lazy projected by fields
+func (sub *subscriber) response(lazy *lazyResponse) *v1.SubscribeResponse {
+	if sub.fields == nil {
+		return lazy.get()
+	}
+
+	return lazy.project(lazy.get(), sub.fields).(*v1.SubscribeResponse)
+}
+
+// responseV2 returns the v2 response of lazy projected by fields
+func (sub *subscriber) responseV2(lazy *lazyResponse) *v2.SubscribeResponse {
+	if sub.fields == nil {
+		return lazy.getV2()
+	}
+
+	return lazy.project(lazy.getV2(), sub.fields).(*v2.SubscribeResponse)
+}
+
+// close waits for a pending send and prevents further sends
+func (sub *subscriber) close() {
+	sub.m_stream.Lock()
+	defer sub.m_stream.Unlock()
+
+	sub.closed = true
+}
+
+// info returns the details and statistics of sub
+func (sub *subscriber) info() *v1.SubscriberInfo {
+	return &v1.SubscriberInfo{
+		Id:             sub.id,
+		Identity:       sub.identity,
+		Peer:           sub.peer,
+		StartedAt:      sub.started.Format(time.RFC3339),
+		GroupAddresses: sub.req.GroupAddresses,
+		Event:          sub.req.Event,
+		Delivered:      sub.delivered.Load(),
+		Filtered:       sub.filtered.Load(),
+		Dropped:        sub.dropped.Load(),
+	}
+}
+
+// setTrailers sets the statistics of sub as trailers of its stream
+func (sub *subscriber) setTrailers() {
+	var trailer http.Header
+	if sub.streamV2 != nil {
+		trailer = sub.streamV2.ResponseTrailer()
+	} else {
+		trailer = sub.stream.ResponseTrailer()
+	}
+	trailer.Set("Knxrpc-Delivered", strconv.FormatUint(sub.delivered.Load(), 10))
+	trailer.Set("Knxrpc-Filtered", strconv.FormatUint(sub.filtered.Load(), 10))
+	trailer.Set("Knxrpc-Dropped", strconv.FormatUint(sub.dropped.Load(), 10))
+}
+
+// subscribe checks the restrictions of sub and registers it at the
+// subscribers or sniffers. The returned func unregisters sub and must be
+// called once done, the error is a *connect.Error.
+func (s *Server) subscribe(sub *subscriber) (func(), error) {
+	// parse group addresses
+	addresses, err := parseGroupAddresses(sub.req.GroupAddresses)
+	if err != nil {
+		return nil, newConnectError(connect.CodeInvalidArgument, err)
+	}
+	sub.addresses = addresses
+
+	// parse the replay limits before registering
+	var replayCount int
+	var replayWithin time.Duration
+	if sub.req.ReplayLast != nil {
+		if len(sub.req.ResumeFrom) > 0 {
+			return nil, newConnectError(connect.CodeInvalidArgument,
+				fieldErrorf("replay_last", "replay_last and resume_from are exclusive"))
+		}
+		if s.replay == nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, ErrReplayDisabled)
+		}
+		replayCount, replayWithin, err = parseReplayLast(sub.req.ReplayLast)
+		if err != nil {
+			return nil, newConnectError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	// parse the resume token before registering
+	var resumeFrom uint64
+	if len(sub.req.ResumeFrom) > 0 {
+		resumeFrom, err = s.resume.parseToken(sub.req.ResumeFrom)
+		switch {
+		case errors.Is(err, ErrResumeDisabled):
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		case errors.Is(err, ErrResumeExpired):
+			return nil, connect.NewError(connect.CodeOutOfRange, err)
+		case err != nil:
+			return nil, newConnectError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	// check tenant restrictions
+	if err := sub.tenant.checkGroupAddresses(addresses...); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+	release, err := sub.tenant.acquireSubscriber()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	// check stream quotas
+	releaseStream, err := s.acquireStream(sub.tenant.name())
+	if err != nil {
+		release()
+		return nil, connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	var unsubscribe func()
+	if len(addresses) > 0 {
+		// register group addresses to subscribe
+		s.registerSubscriber(addresses, sub)
+		unsubscribe = func() {
+			s.unregisterSubscriber(addresses, sub)
+			releaseStream()
+			release()
+		}
+	} else {
+		// no filtering on group_addresses -> sniffer
+		s.registerSniffer(sub)
+		unsubscribe = func() {
+			s.unregisterSniffer(sub)
+			releaseStream()
+			release()
+		}
+	}
+
+	// replay after registering so that no event is missed in between
+	var replay func() ([]*lazyResponse, error)
+	switch {
+	case len(sub.req.ResumeFrom) > 0:
+		replay = func() ([]*lazyResponse, error) {
+			entries, err := s.resume.since(resumeFrom)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeOutOfRange, err)
+			}
+			return s.wantedResponses(sub, entries), nil
+		}
+	case sub.req.ReplayLast != nil:
+		replay = func() ([]*lazyResponse, error) {
+			return s.recentResponses(sub, replayCount, replayWithin), nil
+		}
+	}
+	if replay != nil {
+		if err := s.replayResponses(sub, replay); err != nil {
+			unsubscribe()
+			return nil, err
+		}
+	}
+
+	return unsubscribe, nil
+}
+
+// replayResponses sends the responses returned by replay to sub before
+// any new event. New events already replayed are skipped by send.
+func (s *Server) replayResponses(sub *subscriber, replay func() ([]*lazyResponse, error)) error {
+	sub.m_stream.Lock()
+	defer sub.m_stream.Unlock()
+
+	responses, err := replay()
+	if err != nil {
+		return err
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	// events dispatched meanwhile are wanted and buffered, thus replayed
+	sub.replayed = responses[len(responses)-1].sequence
+
+	for _, lazy := range responses {
+		if err := sub.deliver(lazy); err != nil {
+			s.log.Error().
+				Err(err).
+				Str("peer", sub.peer).
+				Msg("unable to replay events to subscriber")
+			break
+		}
+	}
+
+	return nil
+}
+
+// wantedResponses returns the responses of entries sub wants
+func (s *Server) wantedResponses(sub *subscriber, entries []resumeEntry) []*lazyResponse {
+	ret := []*lazyResponse{}
+	for _, entry := range entries {
+		// a fresh response as the dispatched one is not safe for concurrent use
+		lazy := &lazyResponse{
+			server:   s,
+			event:    entry.event,
+			sequence: entry.sequence,
+			received: entry.received,
+		}
+		if sub.wants(lazy) {
+			ret = append(ret, lazy)
+		}
+	}
+
+	return ret
+}
+
+// recentResponses returns the last count responses (any if zero) of each
+// group address sub wants, received within and ordered by their sequence
+func (s *Server) recentResponses(sub *subscriber, count int, within time.Duration) []*lazyResponse {
+	ret := []*lazyResponse{}
+	for _, entries := range s.replay.last(sub.addresses, within) {
+		responses := s.wantedResponses(sub, entries)
+		if count > 0 && len(responses) > count {
+			responses = responses[len(responses)-count:]
+		}
+		ret = append(ret, responses...)
+	}
+	slices.SortFunc(ret, func(a, b *lazyResponse) int {
+		return cmp.Compare(a.sequence, b.sequence)
+	})
+
+	return ret
+}
+
+// wants returns whether sub receives the event of lazy
+// using the same checks as dispatching
+func (sub *subscriber) wants(lazy *lazyResponse) bool {
+	if len(sub.addresses) > 0 {
+		if !slices.Contains(sub.addresses, lazy.event.Destination) {
+			return false
+		}
+	} else if !sub.tenant.allows(lazy.event.Destination) {
+		return false
+	}
+
+	return sub.req.Event == v1.Event_EVENT_UNSPECIFIED ||
+		sub.req.Event == lazy.get().Event
 }
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sort"
+
+	"github.com/choopm/knxrpc/web"
+	"github.com/labstack/echo/v4"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// swaggerSecurityDefinition is the name of the security definition in the spec
+const swaggerSecurityDefinition = "ApiKeyAuth"
+
+// Version is the version of the running build reported in the served spec.
+// It falls back to the module version from the build info if unset.
+var Version string
+
+// buildVersion returns Version or the module version of the build
+func buildVersion() string {
+	if len(Version) > 0 {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	return "unknown"
+}
+
+// swaggerSpec returns the embedded swagger spec adjusted to the running server
+// as seen by the request of c: server URL, build version, auth header and scheme
+// and examples using the group address directory.
+func (s *Server) swaggerSpec(c echo.Context) (map[string]any, error) {
+	raw, err := web.SwaggerFS.ReadFile("swagger/all.swagger.json")
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]any{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("parse swagger spec: %s", err)
+	}
+
+	// server URL
+	spec["host"] = c.Request().Host
+	spec["schemes"] = []string{c.Scheme()}
+	spec["basePath"] = "/"
+
+	// version of the running build, the api version is kept
+	if info, ok := spec["info"].(map[string]any); ok {
+		info["x-api-version"] = info["version"]
+		info["version"] = buildVersion()
+	}
+
+	// auth
+	if s.config.RPC.Auth.Enabled {
+		definition := map[string]any{
+			"type": "apiKey",
+			"in":   "header",
+			"name": s.config.RPC.Auth.Header,
+		}
+		if len(s.config.RPC.Auth.Scheme) > 0 {
+			definition["description"] = fmt.Sprintf(
+				"the %q scheme is prepended to the key", s.config.RPC.Auth.Scheme)
+			definition["x-scheme"] = s.config.RPC.Auth.Scheme
+		}
+		spec["securityDefinitions"] = map[string]any{
+			swaggerSecurityDefinition: definition,
+		}
+	} else {
+		delete(spec, "securityDefinitions")
+		delete(spec, "security")
+	}
+
+	// examples
+	definitions, _ := spec["definitions"].(map[string]any)
+	for name, example := range s.swaggerExamples() {
+		definition, ok := definitions[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		definition["example"] = example
+	}
+
+	return spec, nil
+}
+
+// swaggerExamples returns request examples by definition name generated
+// from the group address directory, empty if there is none.
+func (s *Server) swaggerExamples() map[string]any {
+	directory := s.reloadable().directory
+	addresses := []cemi.GroupAddr{}
+	for ga := range directory {
+		addresses = append(addresses, ga)
+	}
+	if len(addresses) == 0 {
+		return map[string]any{}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i] < addresses[j]
+	})
+
+	// prefer a group address with a dpt supporting value encoding
+	ga := addresses[0]
+	value := ""
+	for _, addr := range addresses {
+		entry := directory[addr]
+		if len(entry.DPT) == 0 {
+			continue
+		}
+		if _, err := encodeValue(entry.DPT, "1"); err == nil {
+			ga, value = addr, "1"
+			break
+		}
+	}
+	entry := directory[ga]
+
+	publish := map[string]any{
+		"group_address": ga.String(),
+		"event":         "EVENT_WRITE",
+	}
+	if len(value) > 0 {
+		publish["value"] = value
+		publish["dpt"] = entry.DPT
+	} else {
+		publish["data"] = base64.StdEncoding.EncodeToString([]byte{0x01})
+	}
+
+	groupAddresses := []string{}
+	for _, addr := range addresses[:min(len(addresses), 3)] {
+		groupAddresses = append(groupAddresses, addr.String())
+	}
+	subscribe := map[string]any{
+		"group_addresses": groupAddresses,
+		"event":           "EVENT_UNSPECIFIED",
+	}
+
+	// scan the group of the first group address
+	to := addresses[0]
+	for _, addr := range addresses {
+		if addr>>8 == addresses[0]>>8 {
+			to = addr
+		}
+	}
+
+	// v1 and v2 share message names which are qualified by their package
+	ret := map[string]any{
+		"groupaddressv1PublishRequest":   publish,
+		"groupaddressv1SubscribeRequest": subscribe,
+		"groupaddressv2SubscribeRequest": subscribe,
+		"v1SubscribeUnaryRequest": map[string]any{
+			"subscribe_request": subscribe,
+			"for":               "10s",
+		},
+		"v1ScanRequest": map[string]any{
+			"from":     addresses[0].String(),
+			"to":       to.String(),
+			"interval": "100ms",
+		},
+	}
+	if len(entry.DPT) > 0 {
+		ret["v1AggregateRequest"] = map[string]any{
+			"group_address": ga.String(),
+			"dpt":           entry.DPT,
+			"bucket":        "1h",
+		}
+	}
+
+	return ret
+}
+
+// swaggerSpecHandler serves the swagger spec adjusted to the running server
+func (s *Server) swaggerSpecHandler(c echo.Context) error {
+	spec, err := s.swaggerSpec(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, spec)
+}
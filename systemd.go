@@ -0,0 +1,130 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// sdListenFdsStart is the first file descriptor passed by systemd
+const sdListenFdsStart = 3
+
+// sdNotify sends state to the systemd notify socket,
+// it does nothing unless started as a Type=notify service.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if len(socket) == 0 {
+		return nil
+	}
+	// abstract socket
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("sd_notify: %s", err)
+	}
+	defer conn.Close() // nolint:errcheck
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("sd_notify: %s", err)
+	}
+
+	return nil
+}
+
+// sdWatchdogInterval returns the systemd WatchdogSec of this process or 0
+func sdWatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); len(pid) > 0 && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond
+}
+
+// sdListener returns the first socket passed by systemd socket activation
+// or nil if not socket activated.
+func sdListener() (net.Listener, error) {
+	if os.Getenv("LISTEN_PID") != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil
+	}
+	// do not pass the sockets to child processes
+	os.Unsetenv("LISTEN_PID")     // nolint:errcheck
+	os.Unsetenv("LISTEN_FDS")     // nolint:errcheck
+	os.Unsetenv("LISTEN_FDNAMES") // nolint:errcheck
+
+	f := os.NewFile(sdListenFdsStart, "LISTEN_FD_3")
+	defer f.Close() // nolint:errcheck
+
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("socket activation: %s", err)
+	}
+
+	return l, nil
+}
+
+// listen binds the webserver listener, preferring a socket passed by
+// systemd socket activation over rpc.webserver.host and port.
+func (s *Server) listen() error {
+	l, err := sdListener()
+	if err != nil {
+		return err
+	}
+	if l != nil {
+		s.log.Info().
+			Str("hostport", l.Addr().String()).
+			Msg("using systemd socket activation for the webserver")
+	} else {
+		lc := net.ListenConfig{}
+		if s.config.RPC.Webserver.ReusePort {
+			lc.Control = reusePort
+		}
+		l, err = lc.Listen(s.ctx, "tcp", net.JoinHostPort(
+			s.config.RPC.Webserver.Host,
+			strconv.Itoa(s.config.RPC.Webserver.Port),
+		))
+		if err != nil {
+			return err
+		}
+		s.log.Debug().
+			Str("hostport", l.Addr().String()).
+			Msg("not socket activated, listening on rpc.webserver")
+	}
+	if s.config.RPC.Webserver.ProxyProtocol {
+		l = &proxyProtocolListener{
+			Listener: l,
+			trusted:  s.trustedProxies,
+		}
+	}
+	s.e.Listener = l
+
+	return nil
+}
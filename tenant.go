@@ -0,0 +1,242 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"connectrpc.com/authn"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrTenantGroupAddress   = errors.New("group address not allowed for tenant")
+	ErrTenantRateLimited    = errors.New("tenant publish rate exceeded")
+	ErrTenantQuotaExhausted = errors.New("tenant subscriber quota exhausted")
+	ErrTenantRestricted     = errors.New("not allowed for tenants")
+)
+
+// TenantConfig holds the config of a tenant sharing this instance
+type TenantConfig struct {
+	// Name identifies the tenant, required
+	Name string `mapstructure:"name"`
+
+	// SecretKeys authenticate RPCs of this tenant using rpc.auth.header, required
+	SecretKeys []string `mapstructure:"secretKeys"`
+
+	// SecretKeysFile is a file to read additional SecretKeys from, one per line, optional
+	SecretKeysFile string `mapstructure:"secretKeysFile"`
+
+	// GroupAddresses lists group address patterns this tenant may use, required
+	// valid format: 1/2/3, 1/2/*, 1/2/10-20
+	GroupAddresses []string `mapstructure:"groupAddresses"`
+
+	// PublishRate limits publishes per second, optional (0 means unlimited)
+	PublishRate float64 `mapstructure:"publishRate"`
+
+	// PublishBurst is the amount of publishes allowed at once, optional (defaults to 1)
+	PublishBurst int `mapstructure:"publishBurst"`
+
+	// MaxSubscribers limits concurrent Subscribe streams, optional (0 means unlimited)
+	MaxSubscribers int `mapstructure:"maxSubscribers"`
+}
+
+// Validate validates the TenantConfig
+func (c *TenantConfig) Validate() error {
+	if len(c.Name) == 0 {
+		return fmt.Errorf("missing rpc.tenants.name")
+	}
+	if len(c.SecretKeys) == 0 {
+		return fmt.Errorf("missing rpc.tenants(%s).secretKeys", c.Name)
+	}
+	for _, key := range c.SecretKeys {
+		if len(key) == 0 {
+			return fmt.Errorf("empty rpc.tenants(%s).secretKeys", c.Name)
+		}
+		if err := validateSecret(key); err != nil {
+			return fmt.Errorf("invalid rpc.tenants(%s).secretKeys: %s", c.Name, err)
+		}
+	}
+	if len(c.GroupAddresses) == 0 {
+		return fmt.Errorf("missing rpc.tenants(%s).groupAddresses", c.Name)
+	}
+	if _, err := parseGroupAddressPatterns(c.GroupAddresses); err != nil {
+		return fmt.Errorf("rpc.tenants(%s).groupAddresses: %s", c.Name, err)
+	}
+	if c.PublishRate < 0 {
+		return fmt.Errorf("invalid rpc.tenants(%s).publishRate", c.Name)
+	}
+	if c.PublishBurst < 0 {
+		return fmt.Errorf("invalid rpc.tenants(%s).publishBurst", c.Name)
+	}
+	if c.MaxSubscribers < 0 {
+		return fmt.Errorf("invalid rpc.tenants(%s).maxSubscribers", c.Name)
+	}
+
+	return nil
+}
+
+// tenant stores the runtime state of a tenant.
+// A nil *tenant is unrestricted and used for the global rpc.auth.secretKey.
+type tenant struct {
+	config *TenantConfig
+
+	// groupAddresses stores the parsed TenantConfig.GroupAddresses
+	groupAddresses []groupAddressPattern
+
+	// limiter limits publishes, nil if unlimited
+	limiter *rate.Limiter
+
+	// subscribers counts the open Subscribe streams, kept on reloads
+	subscribers *tenantSubscribers
+}
+
+// tenantSubscribers counts the open Subscribe streams of a tenant
+type tenantSubscribers struct {
+	count int
+	// m_count synchronizes access to count
+	m_count sync.Mutex
+}
+
+// newTenant returns a *tenant from config or error
+func newTenant(config *TenantConfig) (*tenant, error) {
+	groupAddresses, err := parseGroupAddressPatterns(config.GroupAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tenant{
+		config:         config,
+		groupAddresses: groupAddresses,
+		subscribers:    &tenantSubscribers{},
+	}
+
+	if config.PublishRate > 0 {
+		burst := config.PublishBurst
+		if burst == 0 {
+			burst = 1
+		}
+		t.limiter = rate.NewLimiter(rate.Limit(config.PublishRate), burst)
+	}
+
+	return t, nil
+}
+
+// newTenants returns all configured tenants or error
+func newTenants(configs []TenantConfig) ([]*tenant, error) {
+	ret := []*tenant{}
+
+	for i := range configs {
+		t, err := newTenant(&configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %s", configs[i].Name, err)
+		}
+
+		ret = append(ret, t)
+	}
+
+	return ret, nil
+}
+
+// tenantFromContext returns the authenticated *tenant of ctx,
+// nil if unrestricted.
+func tenantFromContext(ctx context.Context) *tenant {
+	t, _ := authn.GetInfo(ctx).(*tenant)
+	return t
+}
+
+// name returns the tenant name
+func (t *tenant) name() string {
+	if t == nil {
+		return ""
+	}
+
+	return t.config.Name
+}
+
+// allows returns whether the tenant may use ga
+func (t *tenant) allows(ga cemi.GroupAddr) bool {
+	if t == nil {
+		return true
+	}
+
+	return matchGroupAddressPatterns(t.groupAddresses, ga)
+}
+
+// checkGroupAddresses returns an error wrapping ErrTenantGroupAddress
+// if any of addresses is not allowed.
+func (t *tenant) checkGroupAddresses(addresses ...cemi.GroupAddr) error {
+	for _, ga := range addresses {
+		if !t.allows(ga) {
+			return newGroupAddressError(reasonTenantGroupAddress, ga,
+				fmt.Errorf("%w: %s", ErrTenantGroupAddress, ga))
+		}
+	}
+
+	return nil
+}
+
+// checkRestricted returns ErrTenantRestricted for any tenant
+func (t *tenant) checkRestricted() error {
+	if t != nil {
+		return ErrTenantRestricted
+	}
+
+	return nil
+}
+
+// allowPublish returns ErrTenantRateLimited if the publish rate is exceeded
+func (t *tenant) allowPublish() error {
+	if t == nil || t.limiter == nil {
+		return nil
+	}
+
+	if !t.limiter.Allow() {
+		return ErrTenantRateLimited
+	}
+
+	return nil
+}
+
+// acquireSubscriber reserves a Subscribe stream or returns ErrTenantQuotaExhausted.
+// The returned func must be called to release it.
+func (t *tenant) acquireSubscriber() (func(), error) {
+	if t == nil {
+		return func() {}, nil
+	}
+
+	// streams are counted even if unlimited as a reload may limit them
+	subscribers := t.subscribers
+	subscribers.m_count.Lock()
+	defer subscribers.m_count.Unlock()
+
+	if t.config.MaxSubscribers > 0 && subscribers.count >= t.config.MaxSubscribers {
+		return nil, ErrTenantQuotaExhausted
+	}
+	subscribers.count++
+
+	return func() {
+		subscribers.m_count.Lock()
+		defer subscribers.m_count.Unlock()
+
+		subscribers.count--
+	}, nil
+}
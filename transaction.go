@@ -0,0 +1,300 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// maxTransactions limits the amount of open transactions
+const maxTransactions = 256
+
+var (
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrTransactionTooMany    = errors.New("too many transactions")
+	ErrTransactionNoResponse = errors.New("group address did not respond")
+)
+
+// transaction stores the previous values of an applied transaction
+type transaction struct {
+	id string
+
+	// tenant which started it, nil if unrestricted
+	tenant *tenant
+
+	// previous stores the write events restoring previous values
+	previous []*knx.GroupEvent
+
+	// timer reverts the transaction on expiry, nil if it never expires
+	timer *time.Timer
+}
+
+// parseTransactionRequest returns the write events and expiry of req or error
+func parseTransactionRequest(req *v1.TransactionRequest) ([]*knx.GroupEvent, time.Duration, error) {
+	if len(req.Writes) == 0 {
+		return nil, 0, fieldErrorf("writes", "missing writes")
+	}
+
+	writes := []*knx.GroupEvent{}
+	seen := map[cemi.GroupAddr]bool{}
+	for i, w := range req.Writes {
+		ga, err := cemi.NewGroupAddrString(w.GroupAddress)
+		if err != nil {
+			return nil, 0, fieldErrorf(fmt.Sprintf("writes[%d].group_address", i),
+				"parse writes(%d).groupAddress: %s", i, err)
+		}
+		if seen[ga] {
+			return nil, 0, fieldErrorf(fmt.Sprintf("writes[%d].group_address", i),
+				"duplicate writes(%d).groupAddress: %s", i, ga)
+		}
+		seen[ga] = true
+
+		writes = append(writes, &knx.GroupEvent{
+			Command:     knx.GroupWrite,
+			Destination: ga,
+			Data:        w.Data,
+		})
+	}
+
+	var expires time.Duration
+	if len(req.Expires) > 0 {
+		var err error
+		expires, err = time.ParseDuration(req.Expires)
+		if err != nil {
+			return nil, 0, fieldErrorf("expires", "parsing 'expires': %v", err)
+		}
+		if expires <= 0 {
+			return nil, 0, fieldErrorf("expires", "invalid expires %s", expires)
+		}
+	}
+
+	return writes, expires, nil
+}
+
+// beginTransaction reads the current values of all writes, applies the writes
+// on behalf of tenant t and stores the previous values for reverting.
+func (s *Server) beginTransaction(
+	ctx context.Context,
+	t *tenant,
+	writes []*knx.GroupEvent,
+	expires time.Duration,
+) (*v1.TransactionResponse, error) {
+	// fail early before anything was written
+	publishFilter := s.reloadable().publishFilter
+	addresses := []cemi.GroupAddr{}
+	for _, w := range writes {
+		if err := publishFilter.check(w); err != nil {
+			return nil, newConnectError(connect.CodePermissionDenied, err)
+		}
+		addresses = append(addresses, w.Destination)
+	}
+	s.m_transactions.Lock()
+	full := len(s.transactions) >= maxTransactions
+	s.m_transactions.Unlock()
+	if full {
+		return nil, connect.NewError(connect.CodeResourceExhausted, ErrTransactionTooMany)
+	}
+
+	// read current values
+	values, err := s.readGroupAddresses(ctx, addresses)
+	if err != nil {
+		return nil, toTransactionError(err)
+	}
+
+	tx := &transaction{
+		tenant:   t,
+		previous: []*knx.GroupEvent{},
+	}
+	res := &v1.TransactionResponse{
+		Previous: []*v1.GroupAddressValue{},
+	}
+	for _, ga := range addresses {
+		tx.previous = append(tx.previous, &knx.GroupEvent{
+			Command:     knx.GroupWrite,
+			Destination: ga,
+			Data:        values[ga],
+		})
+		res.Previous = append(res.Previous, &v1.GroupAddressValue{
+			GroupAddress: ga.String(),
+			Data:         values[ga],
+		})
+	}
+
+	// apply writes, restore already applied ones on failure
+	for i, w := range writes {
+		if _, err := s.publish(t, w); err != nil {
+			s.restore(tx.previous[:i]) // nolint:errcheck
+			return nil, err
+		}
+	}
+
+	tx.id, err = newRandomID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	res.Id = tx.id
+
+	s.m_transactions.Lock()
+	defer s.m_transactions.Unlock()
+
+	if expires > 0 {
+		tx.timer = time.AfterFunc(expires, func() {
+			if err := s.revertTransaction(t, tx.id); err != nil {
+				s.log.Error().
+					Err(err).
+					Str("id", tx.id).
+					Msg("unable to revert expired transaction")
+				return
+			}
+
+			s.log.Debug().
+				Str("id", tx.id).
+				Msg("reverted expired transaction")
+		})
+	}
+	s.transactions[tx.id] = tx
+
+	return res, nil
+}
+
+// commitTransaction forgets the previous values of transaction id visible
+// to tenant t or returns ErrTransactionNotFound.
+func (s *Server) commitTransaction(t *tenant, id string) error {
+	_, err := s.removeTransaction(t, id)
+	return err
+}
+
+// revertTransaction restores the previous values of transaction id visible
+// to tenant t or returns an error.
+func (s *Server) revertTransaction(t *tenant, id string) error {
+	tx, err := s.removeTransaction(t, id)
+	if err != nil {
+		return err
+	}
+
+	return s.restore(tx.previous)
+}
+
+// removeTransaction removes and returns transaction id visible to tenant t
+// or returns ErrTransactionNotFound.
+func (s *Server) removeTransaction(t *tenant, id string) (*transaction, error) {
+	s.m_transactions.Lock()
+	defer s.m_transactions.Unlock()
+
+	tx, ok := s.transactions[id]
+	if !ok || (t != nil && tx.tenant != t) {
+		return nil, ErrTransactionNotFound
+	}
+
+	if tx.timer != nil {
+		tx.timer.Stop()
+	}
+	delete(s.transactions, id)
+
+	return tx, nil
+}
+
+// restore writes previous values in reverse order and returns the first error.
+// Tenant rate limits do not apply since restoring must not fail halfway.
+func (s *Server) restore(previous []*knx.GroupEvent) error {
+	var ret error
+
+	for i := len(previous) - 1; i >= 0; i-- {
+		if _, err := s.publish(nil, previous[i]); err != nil {
+			s.log.Error().
+				Err(err).
+				Str("group-address", previous[i].Destination.String()).
+				Msg("unable to restore previous value")
+			if ret == nil {
+				ret = err
+			}
+		}
+	}
+
+	return ret
+}
+
+// readGroupAddresses sends read requests to addresses and returns the data
+// of their first response or write within knx.timeout.
+func (s *Server) readGroupAddresses(
+	ctx context.Context,
+	addresses []cemi.GroupAddr,
+) (map[cemi.GroupAddr][]byte, error) {
+	events, unregister := s.registerEventListener()
+	defer unregister()
+
+	wanted := map[cemi.GroupAddr]bool{}
+	for _, ga := range addresses {
+		wanted[ga] = true
+
+		err := s.sendGroupEvent(&knx.GroupEvent{
+			Command:     knx.GroupRead,
+			Destination: ga,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", ga, err)
+		}
+	}
+
+	values := map[cemi.GroupAddr][]byte{}
+	timeout := time.After(s.config.KNX.Timeout)
+	for len(values) < len(addresses) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			for _, ga := range addresses {
+				if _, ok := values[ga]; !ok {
+					return nil, fmt.Errorf("%w: %s", ErrTransactionNoResponse, ga)
+				}
+			}
+		case event := <-events:
+			if event.Command == knx.GroupRead || !wanted[event.Destination] {
+				continue
+			}
+			if _, ok := values[event.Destination]; ok {
+				continue
+			}
+			values[event.Destination] = event.Data
+		}
+	}
+
+	return values, nil
+}
+
+// toTransactionError maps errors of reading group addresses to connect errors
+func toTransactionError(err error) error {
+	switch {
+	case errors.Is(err, ErrTransactionNoResponse):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, ErrPublishDenied):
+		return newConnectError(connect.CodePermissionDenied, err)
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeCanceled, err)
+	}
+
+	return connect.NewError(connect.CodeInternal, err)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	v1 "github.com/choopm/knxrpc/knx/groupaddress/v1"
+	"github.com/vapourismo/knx-go/knx/cemi"
+)
+
+// tunnelAddress learns the individual address the gateway assigned to the
+// tunnel. The connect response carrying it is not exposed by the knx library,
+// but gateways replace the source 0.0.0 by it when confirming a request.
+type tunnelAddress struct {
+	// address is the learned individual address, 0 until learned
+	address atomic.Uint32
+
+	// pending stores requests sent using source 0.0.0 until confirmed
+	pending map[repeatKey]struct{}
+	// m_pending synchronizes access to pending
+	m_pending sync.Mutex
+}
+
+// newTunnelAddress returns a fresh *tunnelAddress
+func newTunnelAddress() *tunnelAddress {
+	return &tunnelAddress{
+		pending: map[repeatKey]struct{}{},
+	}
+}
+
+// get returns the learned individual address, 0 if unknown
+func (t *tunnelAddress) get() cemi.IndividualAddr {
+	return cemi.IndividualAddr(t.address.Load())
+}
+
+// key returns the repeatKey of ldata ignoring its source
+func (t *tunnelAddress) key(ldata *cemi.LData) repeatKey {
+	key := repeatKey{
+		destination: ldata.Destination,
+	}
+	if app, ok := ldata.Data.(*cemi.AppData); ok {
+		key.command = app.Command
+		key.data = string(app.Data)
+		// empty data is packed as 0 and confirmed as such
+		if len(app.Data) == 0 {
+			key.data = "\x00"
+		}
+	}
+
+	return key
+}
+
+// fill sets the source of ldata to the learned address if unset, otherwise
+// it remembers ldata to learn the address from its confirmation
+func (t *tunnelAddress) fill(ldata *cemi.LData) {
+	if ldata.Source != 0 {
+		return
+	}
+	if address := t.get(); address != 0 {
+		ldata.Source = address
+		return
+	}
+
+	t.m_pending.Lock()
+	defer t.m_pending.Unlock()
+
+	t.pending[t.key(ldata)] = struct{}{}
+}
+
+// confirmed learns the address from con if it confirms a request
+// sent using source 0.0.0
+func (t *tunnelAddress) confirmed(con *cemi.LDataCon) {
+	if t.get() != 0 {
+		return
+	}
+
+	t.m_pending.Lock()
+	defer t.m_pending.Unlock()
+
+	key := t.key(&con.LData)
+	if _, ok := t.pending[key]; !ok {
+		return
+	}
+	delete(t.pending, key)
+	if con.Source != 0 {
+		t.address.Store(uint32(con.Source))
+		t.pending = map[repeatKey]struct{}{}
+	}
+}
+
+// GetStatus implements knx.groupaddressservice.v1.GetStatus
+func (s *Server) GetStatus(
+	ctx context.Context,
+	req *connect.Request[v1.GetStatusRequest],
+) (*connect.Response[v1.GetStatusResponse], error) {
+	// the tunnel is shared by all tenants
+	if err := tenantFromContext(ctx).checkRestricted(); err != nil {
+		return nil, newConnectError(connect.CodePermissionDenied, err)
+	}
+
+	res := &v1.GetStatusResponse{
+		Gateway:     gatewayAddress(&s.config.KNX),
+		ConnectedAt: s.connected.Format(time.RFC3339),
+	}
+	if address := s.tunnelAddress.get(); address != 0 {
+		res.IndividualAddress = address.String()
+	}
+
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VaultConfig holds the HashiCorp Vault config
+type VaultConfig struct {
+	// Address of vault, required
+	// valid format: https://vault.example.com:8200
+	Address string `mapstructure:"address"`
+
+	// Token to authenticate with, required
+	Token string `mapstructure:"token"`
+
+	// TokenFile is a file to read Token from, optional
+	TokenFile string `mapstructure:"tokenFile"`
+
+	// Namespace to use, optional
+	Namespace string `mapstructure:"namespace"`
+
+	// Mount is the path of the KV version 2 secrets engine
+	Mount string `mapstructure:"mount" default:"secret"`
+}
+
+// Validate validates the VaultConfig
+func (c *VaultConfig) Validate() error {
+	if len(c.Address) == 0 {
+		return fmt.Errorf("missing rpc.secrets.vault.address")
+	}
+	if _, err := url.Parse(c.Address); err != nil {
+		return fmt.Errorf("invalid rpc.secrets.vault.address: %s", err)
+	}
+	if len(c.Token) == 0 {
+		return fmt.Errorf("missing rpc.secrets.vault.token")
+	}
+	if len(c.Mount) == 0 {
+		return fmt.Errorf("missing rpc.secrets.vault.mount")
+	}
+
+	return nil
+}
+
+// vaultProvider fetches secrets from a vault KV version 2 secrets engine
+type vaultProvider struct {
+	config *VaultConfig
+	client *http.Client
+}
+
+// newVaultProvider returns a *vaultProvider from config
+func newVaultProvider(config *VaultConfig, timeout time.Duration) *vaultProvider {
+	return &vaultProvider{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// fetch implements secretsProvider, key defaults to "value"
+func (p *vaultProvider) fetch(ctx context.Context, path, key string) (string, error) {
+	if len(key) == 0 {
+		key = "value"
+	}
+
+	u, err := url.JoinPath(p.config.Address, "v1",
+		strings.Trim(p.config.Mount, "/"), "data", strings.Trim(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+	if len(p.config.Namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close() // nolint:errcheck
+
+	if res.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, res.Body) // nolint:errcheck
+		return "", fmt.Errorf("vault returned %s", res.Status)
+	}
+
+	body := struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding vault response: %s", err)
+	}
+
+	val, ok := body.Data.Data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("missing string key %s", key)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2024 Christoph Hoopmann
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knxrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vapourismo/knx-go/knx"
+	"github.com/vapourismo/knx-go/knx/cemi"
+	"github.com/vapourismo/knx-go/knx/dpt"
+)
+
+// VirtualGroupAddressConfig holds a group address whose value is computed
+// from the values of other group addresses
+type VirtualGroupAddressConfig struct {
+	// Address is the virtual group address, required
+	// valid format: 1/2/3
+	Address string `mapstructure:"address"`
+
+	// Name is a human readable name, optional
+	Name string `mapstructure:"name"`
+
+	// DPT is the datapoint type used to encode the computed value, required
+	// valid format: 9.001
+	DPT string `mapstructure:"dpt"`
+
+	// Function computes the value from the last values of Sources, required
+	// oneof: avg|min|max|sum|and|or
+	Function string `mapstructure:"function"`
+
+	// Sources lists the group addresses to compute the value from, required
+	// each one needs a dpt in knx.groupAddresses
+	Sources []string `mapstructure:"sources"`
+
+	// Publish writes changed values to the bus and responds to reads,
+	// they are only delivered to subscribers otherwise
+	Publish bool `mapstructure:"publish" default:"false"`
+}
+
+// Validate validates the VirtualGroupAddressConfig
+func (c *VirtualGroupAddressConfig) Validate() error {
+	if _, err := cemi.NewGroupAddrString(c.Address); err != nil {
+		return fmt.Errorf("knx.virtualGroupAddresses(%s): %s", c.Address, err)
+	}
+	if _, ok := dpt.Produce(c.DPT); !ok {
+		return fmt.Errorf("knx.virtualGroupAddresses(%s): unsupported dpt: %s", c.Address, c.DPT)
+	}
+	if _, ok := virtualFunctions[strings.ToLower(c.Function)]; !ok {
+		return fmt.Errorf("knx.virtualGroupAddresses(%s): unsupported function: %s", c.Address, c.Function)
+	}
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("knx.virtualGroupAddresses(%s): missing sources", c.Address)
+	}
+	for _, source := range c.Sources {
+		if _, err := cemi.NewGroupAddrString(source); err != nil {
+			return fmt.Errorf("knx.virtualGroupAddresses(%s): sources: %s", c.Address, err)
+		}
+	}
+
+	return nil
+}
+
+// validateVirtualGroupAddresses validates c.VirtualGroupAddresses and
+// their sources against c.GroupAddresses
+func (c *KNXConfig) validateVirtualGroupAddresses() error {
+	for i := range c.VirtualGroupAddresses {
+		if err := c.VirtualGroupAddresses[i].Validate(); err != nil {
+			return err
+		}
+	}
+	directory, err := newDirectory(c.directoryEntries())
+	if err != nil {
+		return fmt.Errorf("knx.virtualGroupAddresses: %s", err)
+	}
+
+	for _, v := range c.VirtualGroupAddresses {
+		for _, source := range v.Sources {
+			ga, _ := cemi.NewGroupAddrString(source)
+			entry := directory[ga]
+			if entry == nil || len(entry.DPT) == 0 {
+				return fmt.Errorf("knx.virtualGroupAddresses(%s): source %s requires a dpt in knx.groupAddresses",
+					v.Address, source)
+			}
+			if slices.ContainsFunc(c.VirtualGroupAddresses, func(other VirtualGroupAddressConfig) bool {
+				addr, _ := cemi.NewGroupAddrString(other.Address)
+				return addr == ga
+			}) {
+				return fmt.Errorf("knx.virtualGroupAddresses(%s): source %s must not be virtual",
+					v.Address, source)
+			}
+		}
+	}
+
+	return nil
+}
+
+// directoryEntries returns c.GroupAddresses and c.VirtualGroupAddresses
+// as entries of the group address directory
+func (c *KNXConfig) directoryEntries() []GroupAddressConfig {
+	ret := slices.Clone(c.GroupAddresses)
+	for _, v := range c.VirtualGroupAddresses {
+		ret = append(ret, GroupAddressConfig{
+			Address: v.Address,
+			Name:    v.Name,
+			DPT:     v.DPT,
+		})
+	}
+
+	return ret
+}
+
+// virtualFunctions computes the value of a virtual group address from the values of its sources
+var virtualFunctions = map[string]func(values []float64) float64{
+	"avg": func(values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	},
+	"min": func(values []float64) float64 {
+		return slices.Min(values)
+	},
+	"max": func(values []float64) float64 {
+		return slices.Max(values)
+	},
+	"sum": func(values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	},
+	"and": func(values []float64) float64 {
+		if slices.Contains(values, 0) {
+			return 0
+		}
+		return 1
+	},
+	"or": func(values []float64) float64 {
+		for _, v := range values {
+			if v != 0 {
+				return 1
+			}
+		}
+		return 0
+	},
+}
+
+// virtualGroupAddress is the state of a virtual group address,
+// it is only used by the virtual updater and not synchronized.
+type virtualGroupAddress struct {
+	config   *VirtualGroupAddressConfig
+	address  cemi.GroupAddr
+	function func(values []float64) float64
+
+	// values stores the last value of each source seen so far
+	values map[cemi.GroupAddr]float64
+	// data is the last computed value, nil until a source was seen
+	data []byte
+}
+
+// update stores value of source and returns the recomputed data or error
+func (v *virtualGroupAddress) update(source cemi.GroupAddr, value float64) ([]byte, error) {
+	v.values[source] = value
+
+	values := make([]float64, 0, len(v.values))
+	for _, value := range v.values {
+		values = append(values, value)
+	}
+
+	return encodeNumericValue(v.config.DPT, v.function(values))
+}
+
+// newVirtualGroupAddresses returns the virtual group addresses of configs
+// by their sources and by their address or error
+func newVirtualGroupAddresses(configs []VirtualGroupAddressConfig) (
+	bySource map[cemi.GroupAddr][]*virtualGroupAddress,
+	byAddress map[cemi.GroupAddr]*virtualGroupAddress,
+	err error,
+) {
+	bySource = map[cemi.GroupAddr][]*virtualGroupAddress{}
+	byAddress = map[cemi.GroupAddr]*virtualGroupAddress{}
+
+	for i := range configs {
+		v := &virtualGroupAddress{
+			config:   &configs[i],
+			function: virtualFunctions[strings.ToLower(configs[i].Function)],
+			values:   map[cemi.GroupAddr]float64{},
+		}
+		v.address, err = cemi.NewGroupAddrString(configs[i].Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse address(%d): %s", i, err)
+		}
+		byAddress[v.address] = v
+
+		sources, err := parseGroupAddresses(configs[i].Sources)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: sources: %s", v.address, err)
+		}
+		for _, source := range sources {
+			if !slices.Contains(bySource[source], v) {
+				bySource[source] = append(bySource[source], v)
+			}
+		}
+	}
+
+	return bySource, byAddress, nil
+}
+
+// virtualUpdater recomputes virtual group addresses whenever one of their
+// sources carries a value until ctx is done. Changed values are dispatched
+// and written to the bus if published.
+func (s *Server) virtualUpdater(ctx context.Context) error {
+	bySource, byAddress, err := newVirtualGroupAddresses(s.config.KNX.VirtualGroupAddresses)
+	if err != nil {
+		return fmt.Errorf("knx.virtualGroupAddresses: %s", err)
+	}
+
+	events, unregister := s.registerEventListener()
+	defer unregister()
+
+	for {
+		var event *knx.GroupEvent
+		select {
+		case <-ctx.Done():
+			return nil
+		case event = <-events:
+		}
+
+		// answer reads of published virtual group addresses
+		if event.Command == knx.GroupRead {
+			if v, ok := byAddress[event.Destination]; ok && v.config.Publish && v.data != nil {
+				s.writeVirtual(v, knx.GroupResponse)
+			}
+			continue
+		}
+
+		virtuals := bySource[event.Destination]
+		if len(virtuals) == 0 {
+			continue
+		}
+		entry, ok := s.reloadable().directory[event.Destination]
+		if !ok {
+			continue
+		}
+		value, ok := numericValue(entry.DPT, event.Data)
+		if !ok {
+			s.log.Warn().
+				Str("group-address", event.Destination.String()).
+				Str("dpt", entry.DPT).
+				Msg("unable to decode source of virtual group address")
+			continue
+		}
+
+		for _, v := range virtuals {
+			data, err := v.update(event.Destination, value)
+			if err != nil {
+				s.log.Warn().
+					Err(err).
+					Str("group-address", v.address.String()).
+					Msg("unable to encode value of virtual group address")
+				continue
+			}
+			if bytes.Equal(data, v.data) {
+				continue
+			}
+			v.data = data
+			s.writeVirtual(v, knx.GroupWrite)
+		}
+	}
+}
+
+// writeVirtual dispatches the data of v using command
+// and writes it to the bus if published
+func (s *Server) writeVirtual(v *virtualGroupAddress, command knx.GroupCommand) {
+	event := &knx.GroupEvent{
+		Command:     command,
+		Destination: v.address,
+		Data:        v.data,
+	}
+
+	if v.config.Publish {
+		if err := s.sendGroupEvent(event); err != nil {
+			s.log.Warn().
+				Err(err).
+				Str("group-address", v.address.String()).
+				Msg("unable to publish virtual group address")
+		}
+	}
+	if err := s.dispatchEvent(event); err != nil {
+		s.log.Error().
+			Err(err).
+			Str("group-address", v.address.String()).
+			Msg("unable to dispatch virtual group address")
+	}
+}
@@ -6,3 +6,8 @@ import "embed"
 //
 //go:embed swagger
 var SwaggerFS embed.FS
+
+// UIFS is content from ui.
+//
+//go:embed ui
+var UIFS embed.FS